@@ -54,3 +54,39 @@ func FindIf(first, last iterator.ConstIterator, f func(iterator.ConstIterator) b
 	}
 	return last
 }
+
+// MaxElement returns the iterator to the first largest element in range [first, last), or last if the range is empty
+func MaxElement(first, last iterator.ConstIterator, cmps ...comparator.Comparator) iterator.ConstIterator {
+	cmp := comparator.BuiltinTypeComparator
+	if len(cmps) > 0 {
+		cmp = cmps[0]
+	}
+	if first.Equal(last) {
+		return last
+	}
+	largest := first.Clone()
+	for iter := first.Clone(); !iter.Equal(last); iter.Next() {
+		if cmp(iter.Value(), largest.Value()) > 0 {
+			largest = iter.Clone()
+		}
+	}
+	return largest
+}
+
+// MinElement returns the iterator to the first smallest element in range [first, last), or last if the range is empty
+func MinElement(first, last iterator.ConstIterator, cmps ...comparator.Comparator) iterator.ConstIterator {
+	cmp := comparator.BuiltinTypeComparator
+	if len(cmps) > 0 {
+		cmp = cmps[0]
+	}
+	if first.Equal(last) {
+		return last
+	}
+	smallest := first.Clone()
+	for iter := first.Clone(); !iter.Equal(last); iter.Next() {
+		if cmp(iter.Value(), smallest.Value()) < 0 {
+			smallest = iter.Clone()
+		}
+	}
+	return smallest
+}
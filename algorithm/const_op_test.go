@@ -0,0 +1,54 @@
+package algorithm
+
+import (
+	"github.com/liyue201/gostl/ds/deque"
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/ds/vector"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstOpOnVector(t *testing.T) {
+	v := vector.New()
+	assert.True(t, MaxElement(v.Begin(), v.End()).Equal(v.End()))
+	assert.True(t, MinElement(v.Begin(), v.End()).Equal(v.End()))
+
+	for _, value := range []int{3, 7, 1, 7, 1, 5} {
+		v.PushBack(value)
+	}
+	assert.Equal(t, 2, Count(v.Begin(), v.End(), 7))
+	assert.Equal(t, 0, Count(v.Begin(), v.End(), 4))
+	assert.Equal(t, 1, Find(v.Begin(), v.End(), 7).(*vector.VectorIterator).Position())
+	assert.True(t, Find(v.Begin(), v.End(), 4).Equal(v.End()))
+
+	// the first one of the equal extremes
+	assert.Equal(t, 1, MaxElement(v.Begin(), v.End()).(*vector.VectorIterator).Position())
+	assert.Equal(t, 2, MinElement(v.Begin(), v.End()).(*vector.VectorIterator).Position())
+	assert.Equal(t, 1, MaxElement(v.Begin(), v.End(), comparator.Reverse(comparator.IntComparator)).Value())
+}
+
+func TestConstOpOnMap(t *testing.T) {
+	m := treemap.New()
+	for key, value := range []string{"d", "a", "c", "a", "e"} {
+		m.Insert(key, value)
+	}
+	end := m.Find(100)
+	assert.Equal(t, 2, Count(m.Begin(), end, "a"))
+	assert.Equal(t, 1, Find(m.Begin(), end, "a").(*treemap.MapIterator).Key())
+	assert.Equal(t, 4, MaxElement(m.Begin(), end).(*treemap.MapIterator).Key())
+	assert.Equal(t, 1, MinElement(m.Begin(), end).(*treemap.MapIterator).Key())
+	assert.Equal(t, 3, MinElement(m.Find(2), end).(*treemap.MapIterator).Key())
+}
+
+func TestConstOpOnDeque(t *testing.T) {
+	d := deque.New()
+	for i := 0; i < 10; i++ {
+		d.PushFront(i)
+	}
+	var first, last iterator.ConstIterator = d.Begin(), d.End()
+	assert.Equal(t, 9, MaxElement(first, last).Value())
+	assert.Equal(t, 0, MinElement(first, last).Value())
+	assert.Equal(t, 1, Count(first, last, 5))
+}
@@ -6,20 +6,37 @@ import (
 	"encoding/binary"
 )
 
-// GenHashInts generate n hash values by the seed passed
+// GenHashInts generate n hash values by the seed passed.
+// Only the first 8 values are distinct, they are repeated when n > 8. The derivation is kept as it is,
+// because the bits set in the data of BloomFilters persisted by Data depend on it.
+// Use GenChainedHashInts for more than 8 distinct values.
 func GenHashInts(seed []byte, n int) []uint64 {
+	first := toInts(Hash512(seed))
+	hashInts := make([]uint64, 0, n+len(first))
+	for len(hashInts) < n {
+		hashInts = append(hashInts, first...)
+	}
+	return hashInts[:n]
+}
+
+// GenChainedHashInts generate n hash values by the seed passed, every 8 values are the sha512 of the previous 8 ones,
+// so that all the values are distinct in practice. The first 8 values are the same as the ones of GenHashInts.
+func GenChainedHashInts(seed []byte, n int) []uint64 {
 	data := seed
 	var hashInts []uint64
 	for len(hashInts) < n {
-		data := Hash512(data)
-		temp := make([]uint64, len(data)/8)
-		buf := bytes.NewBuffer(data)
-		binary.Read(buf, binary.BigEndian, temp)
-		hashInts = append(hashInts, temp...)
+		data = Hash512(data)
+		hashInts = append(hashInts, toInts(data)...)
 	}
 	return hashInts[:n]
 }
 
+func toInts(data []byte) []uint64 {
+	ints := make([]uint64, len(data)/8)
+	binary.Read(bytes.NewBuffer(data), binary.BigEndian, ints)
+	return ints
+}
+
 // Hash512 return the sha512 of the data passed
 func Hash512(data []byte) []byte {
 	h := sha512.New()
@@ -0,0 +1,31 @@
+package hash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGenHashInts(t *testing.T) {
+	seed := []byte("seed")
+	hashInts := GenHashInts(seed, 20)
+	assert.Equal(t, 20, len(hashInts))
+	// the values repeat every 8 ones, as the data of persisted BloomFilters depends on it
+	for i := 8; i < len(hashInts); i++ {
+		assert.Equal(t, hashInts[i%8], hashInts[i])
+	}
+	assert.Equal(t, hashInts[:3], GenHashInts(seed, 3))
+	assert.Equal(t, 0, len(GenHashInts(seed, 0)))
+}
+
+func TestGenChainedHashInts(t *testing.T) {
+	seed := []byte("seed")
+	hashInts := GenChainedHashInts(seed, 100)
+	assert.Equal(t, 100, len(hashInts))
+	assert.Equal(t, GenHashInts(seed, 8), hashInts[:8])
+
+	seen := make(map[uint64]bool)
+	for _, h := range hashInts {
+		assert.False(t, seen[h])
+		seen[h] = true
+	}
+}
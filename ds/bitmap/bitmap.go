@@ -1,5 +1,7 @@
 package bitmap
 
+import "math/bits"
+
 // Bitmap is a mapping from some domain (for example, a range of integers) to bits. It is also called a bit array or bitmap index
 type Bitmap struct {
 	data []byte
@@ -54,6 +56,15 @@ func (b *Bitmap) IsSet(position uint64) bool {
 	return false
 }
 
+// Count returns the number of bits set 1
+func (b *Bitmap) Count() uint64 {
+	var count uint64
+	for _, v := range b.data {
+		count += uint64(bits.OnesCount8(v))
+	}
+	return count
+}
+
 // Resize resize the bitmap
 func (b *Bitmap) Resize(size uint64) {
 	size = (size + 7) / 8 * 8
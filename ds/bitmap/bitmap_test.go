@@ -65,3 +65,14 @@ func TestResize(t *testing.T) {
 	assert.Equal(t, false, bm.IsSet(20))
 	assert.Equal(t, false, bm.IsSet(77))
 }
+
+func TestBitmapCount(t *testing.T) {
+	b := New(100)
+	assert.Equal(t, uint64(0), b.Count())
+	for i := uint64(0); i < 100; i += 3 {
+		b.Set(i)
+	}
+	assert.Equal(t, uint64(34), b.Count())
+	b.Unset(0)
+	assert.Equal(t, uint64(33), b.Count())
+}
@@ -0,0 +1,181 @@
+package bitset
+
+import (
+	"math/bits"
+)
+
+const (
+	wordSize            = 64
+	defaultGrowthFactor = 2
+)
+
+// Options holds BitSet's options
+type Options struct {
+	capacity     uint64
+	growthFactor float64
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithCapacity preallocates space for capacity bits, so that no allocation is needed until a bit beyond capacity is set.
+// It doesn't change the length of the BitSet.
+func WithCapacity(capacity uint64) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// WithGrowthFactor sets the factor by which the capacity of BitSet grows when it is full, the default is 2.
+// A factor not greater than 1 is ignored.
+func WithGrowthFactor(factor float64) Option {
+	return func(option *Options) {
+		option.growthFactor = factor
+	}
+}
+
+// BitSet is a set of non negative integers stored as bits in a growable []uint64,
+// unlike bitmap.Bitmap, it grows automatically when a bit beyond its length is set.
+type BitSet struct {
+	words        []uint64
+	growthFactor float64
+}
+
+// New news a BitSet which can hold at least size bits without growing
+func New(size uint64, opts ...Option) *BitSet {
+	option := Options{
+		growthFactor: defaultGrowthFactor,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	n := wordsNeeded(size)
+	capacity := wordsNeeded(option.capacity)
+	if capacity < n {
+		capacity = n
+	}
+	return &BitSet{words: make([]uint64, n, capacity), growthFactor: option.growthFactor}
+}
+
+func wordsNeeded(size uint64) uint64 {
+	return (size + wordSize - 1) / wordSize
+}
+
+// grow makes sure b can hold the bit at position
+func (b *BitSet) grow(position uint64) {
+	n := position/wordSize + 1
+	if n <= uint64(len(b.words)) {
+		return
+	}
+	if n <= uint64(cap(b.words)) {
+		// the words beyond the length are still zero, because the length of b never shrinks
+		b.words = b.words[:n]
+		return
+	}
+	factor := b.growthFactor
+	if factor <= 1 {
+		factor = defaultGrowthFactor
+	}
+	newCap := uint64(float64(cap(b.words)) * factor)
+	if newCap < n {
+		newCap = n
+	}
+	words := make([]uint64, n, newCap)
+	copy(words, b.words)
+	b.words = words
+}
+
+// Set sets 1 at position, b grows if position is beyond its length
+func (b *BitSet) Set(position uint64) {
+	b.grow(position)
+	b.words[position/wordSize] |= 1 << (position % wordSize)
+}
+
+// Clear sets 0 at position
+func (b *BitSet) Clear(position uint64) {
+	if position/wordSize >= uint64(len(b.words)) {
+		return
+	}
+	b.words[position/wordSize] &^= 1 << (position % wordSize)
+}
+
+// Test returns whether the position is set 1
+func (b *BitSet) Test(position uint64) bool {
+	if position/wordSize >= uint64(len(b.words)) {
+		return false
+	}
+	return b.words[position/wordSize]&(1<<(position%wordSize)) != 0
+}
+
+// Flip flips the bit at position, b grows if position is beyond its length
+func (b *BitSet) Flip(position uint64) {
+	b.grow(position)
+	b.words[position/wordSize] ^= 1 << (position % wordSize)
+}
+
+// Count returns the number of bits set 1
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Len returns the number of bits b can hold without growing
+func (b *BitSet) Len() uint64 {
+	return uint64(len(b.words)) * wordSize
+}
+
+// Reset sets all bits to 0
+func (b *BitSet) Reset() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// Clone returns a copy of b
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words, growthFactor: b.growthFactor}
+}
+
+// And returns a new BitSet with the bits set in both b and other
+func (b *BitSet) And(other *BitSet) *BitSet {
+	n := len(b.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	result := &BitSet{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+	return result
+}
+
+// Or returns a new BitSet with the bits set in either b or other
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	long, short := b, other
+	if len(long.words) < len(short.words) {
+		long, short = short, long
+	}
+	result := long.Clone()
+	for i, w := range short.words {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// Xor returns a new BitSet with the bits set in exactly one of b and other
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	long, short := b, other
+	if len(long.words) < len(short.words) {
+		long, short = short, long
+	}
+	result := long.Clone()
+	for i, w := range short.words {
+		result.words[i] ^= w
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package bitset
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	b := New(100)
+	assert.Equal(t, uint64(128), b.Len())
+	assert.Equal(t, 0, b.Count())
+
+	positions := []uint64{0, 1, 63, 64, 65, 127}
+	for _, p := range positions {
+		assert.False(t, b.Test(p))
+		b.Set(p)
+		assert.True(t, b.Test(p))
+	}
+	assert.Equal(t, len(positions), b.Count())
+	assert.Equal(t, uint64(128), b.Len())
+
+	b.Clear(64)
+	assert.False(t, b.Test(64))
+	assert.True(t, b.Test(63))
+	assert.True(t, b.Test(65))
+	assert.Equal(t, 5, b.Count())
+
+	b.Flip(64)
+	assert.True(t, b.Test(64))
+	b.Flip(0)
+	assert.False(t, b.Test(0))
+	assert.Equal(t, 5, b.Count())
+
+	b.Clear(10000)
+	assert.False(t, b.Test(10000))
+	assert.Equal(t, uint64(128), b.Len())
+
+	b.Reset()
+	assert.Equal(t, 0, b.Count())
+}
+
+func TestBitSetGrow(t *testing.T) {
+	b := New(0)
+	assert.Equal(t, uint64(0), b.Len())
+	assert.False(t, b.Test(0))
+
+	b.Set(5)
+	assert.Equal(t, uint64(64), b.Len())
+	b.Set(1000)
+	assert.True(t, b.Len() > 1000)
+	assert.True(t, b.Test(5))
+	assert.True(t, b.Test(1000))
+	assert.False(t, b.Test(999))
+
+	b.Flip(5000)
+	assert.True(t, b.Test(5000))
+	assert.Equal(t, 3, b.Count())
+}
+
+func TestBitSetOps(t *testing.T) {
+	a := New(0)
+	b := New(0)
+	for i := uint64(0); i < 200; i += 2 {
+		a.Set(i)
+	}
+	for i := uint64(0); i < 300; i += 3 {
+		b.Set(i)
+	}
+
+	and := a.And(b)
+	or := a.Or(b)
+	xor := a.Xor(b)
+	for i := uint64(0); i < 400; i++ {
+		inA := i < 200 && i%2 == 0
+		inB := i < 300 && i%3 == 0
+		assert.Equal(t, inA && inB, and.Test(i), "and %v", i)
+		assert.Equal(t, inA || inB, or.Test(i), "or %v", i)
+		assert.Equal(t, inA != inB, xor.Test(i), "xor %v", i)
+	}
+	assert.Equal(t, and.Count(), b.And(a).Count())
+	assert.Equal(t, or.Count(), b.Or(a).Count())
+	assert.Equal(t, xor.Count(), b.Xor(a).Count())
+
+	or.Set(1)
+	assert.False(t, a.Test(1))
+	assert.False(t, b.Test(1))
+}
+
+func TestBitSetOptions(t *testing.T) {
+	b := New(64, WithCapacity(1000))
+	assert.Equal(t, uint64(64), b.Len())
+	assert.Equal(t, 16, cap(b.words))
+	words := b.words
+	b.Set(999)
+	assert.Equal(t, uint64(1024), b.Len())
+	assert.True(t, &words[0] == &b.words[0])
+
+	// the capacity is never less than the size
+	b = New(640, WithCapacity(64))
+	assert.Equal(t, 10, cap(b.words))
+
+	b = New(64, WithGrowthFactor(4))
+	b.Set(64)
+	assert.Equal(t, 4, cap(b.words))
+	c := b.Clone()
+	c.Set(64 * 4)
+	assert.Equal(t, 8, cap(c.words))
+
+	// a factor not greater than 1 is ignored
+	b = New(64, WithGrowthFactor(0.5))
+	b.Set(64)
+	assert.Equal(t, 2, cap(b.words))
+}
+
+func benchmarkBitSetSet(b *testing.B, opts ...Option) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bs := New(0, opts...)
+		for j := uint64(0); j < 100000; j++ {
+			bs.Set(j)
+		}
+	}
+}
+
+func BenchmarkBitSetSet(b *testing.B) {
+	benchmarkBitSetSet(b)
+}
+
+func BenchmarkBitSetSetWithCapacity(b *testing.B) {
+	benchmarkBitSetSet(b, WithCapacity(100000))
+}
@@ -0,0 +1,158 @@
+package blockingqueue
+
+import (
+	"github.com/liyue201/gostl/ds/queue"
+	"sync"
+	"time"
+)
+
+// BlockingQueue is a bounded FIFO queue for producer/consumer pipelines, it is goroutine-safe.
+// Producers are blocked when the queue is full, and consumers are blocked when the queue is empty.
+type BlockingQueue struct {
+	queue    *queue.Queue
+	capacity int
+	closed   bool
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+}
+
+// New creates a new BlockingQueue holding at most capacity elements, it panics if capacity is not positive
+func New(capacity int) *BlockingQueue {
+	if capacity <= 0 {
+		panic("blockingqueue: capacity must be positive")
+	}
+	bq := &BlockingQueue{
+		queue:    queue.New(),
+		capacity: capacity,
+	}
+	bq.notEmpty = sync.NewCond(&bq.mu)
+	bq.notFull = sync.NewCond(&bq.mu)
+	return bq
+}
+
+// Put puts value to the tail of the queue, it blocks while the queue is full.
+// It returns false without putting value if the queue is closed.
+func (bq *BlockingQueue) Put(value interface{}) bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	for !bq.closed && bq.queue.Size() >= bq.capacity {
+		bq.notFull.Wait()
+	}
+	return bq.put(value)
+}
+
+// Offer is like Put, but it waits at most timeout for the queue to be not full and returns false if timed out
+func (bq *BlockingQueue) Offer(value interface{}, timeout time.Duration) bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if !bq.waitTimeout(bq.notFull, timeout, func() bool {
+		return bq.closed || bq.queue.Size() < bq.capacity
+	}) {
+		return false
+	}
+	return bq.put(value)
+}
+
+// Take removes and returns the value at the head of the queue, it blocks while the queue is empty.
+// After the queue is closed, the remaining values can still be taken, and then it returns nil and false.
+func (bq *BlockingQueue) Take() (interface{}, bool) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	for !bq.closed && bq.queue.Empty() {
+		bq.notEmpty.Wait()
+	}
+	return bq.take()
+}
+
+// Poll is like Take, but it waits at most timeout for the queue to be not empty and returns nil and false if timed out
+func (bq *BlockingQueue) Poll(timeout time.Duration) (interface{}, bool) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	if !bq.waitTimeout(bq.notEmpty, timeout, func() bool {
+		return bq.closed || !bq.queue.Empty()
+	}) {
+		return nil, false
+	}
+	return bq.take()
+}
+
+// Close closes the queue and wakes up all the waiting producers and consumers, the later Put and Offer will fail.
+// It is safe to call Close more than once.
+func (bq *BlockingQueue) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	bq.closed = true
+	bq.notEmpty.Broadcast()
+	bq.notFull.Broadcast()
+}
+
+// IsClosed returns true if the queue is closed, otherwise returns false
+func (bq *BlockingQueue) IsClosed() bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.closed
+}
+
+// Size returns the number of values in the queue
+func (bq *BlockingQueue) Size() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	return bq.queue.Size()
+}
+
+// Capacity returns the maximum number of values the queue can hold
+func (bq *BlockingQueue) Capacity() int {
+	return bq.capacity
+}
+
+func (bq *BlockingQueue) put(value interface{}) bool {
+	if bq.closed {
+		return false
+	}
+	bq.queue.Push(value)
+	bq.notEmpty.Signal()
+	return true
+}
+
+func (bq *BlockingQueue) take() (interface{}, bool) {
+	value, ok := bq.queue.TryPop()
+	if ok {
+		bq.notFull.Signal()
+	}
+	return value, ok
+}
+
+// waitTimeout waits on cond until ready returns true or timeout elapses, it returns the last result of ready.
+// bq.mu must be held by the caller.
+func (bq *BlockingQueue) waitTimeout(cond *sync.Cond, timeout time.Duration, ready func() bool) bool {
+	if ready() {
+		return true
+	}
+	if timeout <= 0 {
+		return false
+	}
+	deadline := time.Now().Add(timeout)
+	// sync.Cond has no timed wait, so a timer wakes up the waiters when the deadline is reached
+	timer := time.AfterFunc(timeout, func() {
+		bq.mu.Lock()
+		defer bq.mu.Unlock()
+		cond.Broadcast()
+	})
+	defer timer.Stop()
+
+	for !ready() {
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		cond.Wait()
+	}
+	return true
+}
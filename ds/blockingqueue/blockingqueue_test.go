@@ -0,0 +1,142 @@
+package blockingqueue
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	bq := New(2)
+	assert.Equal(t, 2, bq.Capacity())
+	assert.True(t, bq.Put(1))
+	assert.True(t, bq.Offer(2, 0))
+	assert.False(t, bq.Offer(3, 10*time.Millisecond))
+	assert.Equal(t, 2, bq.Size())
+
+	v, ok := bq.Take()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, ok = bq.Poll(0)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	start := time.Now()
+	_, ok = bq.Poll(20 * time.Millisecond)
+	assert.False(t, ok)
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+
+	assert.Panics(t, func() { New(0) })
+}
+
+func TestBlockingQueueBlocks(t *testing.T) {
+	bq := New(1)
+	bq.Put(1)
+
+	done := make(chan struct{})
+	go func() {
+		bq.Put(2)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Put should block when the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+	v, _ := bq.Take()
+	assert.Equal(t, 1, v)
+	<-done
+	v, _ = bq.Take()
+	assert.Equal(t, 2, v)
+
+	// a waiting Poll gets the value put later
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		bq.Put(3)
+	}()
+	v, ok := bq.Poll(time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+func TestBlockingQueueProducerConsumer(t *testing.T) {
+	bq := New(8)
+	producers, consumers, n := 4, 4, 1000
+
+	var pwg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < n; i++ {
+				bq.Put(p*n + i)
+			}
+		}(p)
+	}
+
+	results := make(chan int, producers*n)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, ok := bq.Take()
+				if !ok {
+					return
+				}
+				results <- v.(int)
+			}
+		}()
+	}
+
+	pwg.Wait()
+	bq.Close()
+	cwg.Wait()
+	close(results)
+
+	seen := make(map[int]bool)
+	for v := range results {
+		assert.False(t, seen[v], "duplicate value %v", v)
+		seen[v] = true
+	}
+	assert.Equal(t, producers*n, len(seen))
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	bq := New(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok := bq.Take()
+			assert.False(t, ok)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	bq.Close()
+	wg.Wait()
+
+	bq = New(1)
+	bq.Put(1)
+	done := make(chan bool)
+	go func() {
+		done <- bq.Put(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	bq.Close()
+	bq.Close()
+	assert.False(t, <-done)
+	assert.True(t, bq.IsClosed())
+	assert.False(t, bq.Put(3))
+	assert.False(t, bq.Offer(3, time.Second))
+
+	// the remaining values can be taken after closing
+	v, ok := bq.Take()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	_, ok = bq.Poll(time.Second)
+	assert.False(t, ok)
+}
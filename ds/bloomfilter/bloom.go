@@ -6,12 +6,16 @@ import (
 	"github.com/liyue201/gostl/algorithm/hash"
 	"github.com/liyue201/gostl/ds/bitmap"
 	"github.com/liyue201/gostl/utils/sync"
+	"hash/fnv"
 	"math"
 	gosync "sync"
 )
 
 const salt = "g9hmj2fhgr"
 
+// doubleHashing is set in the k written by Data to tell the data from the one written before double hashing was used
+const doubleHashing = 1 << 63
+
 var defaultLocker sync.FakeLocker
 
 // Options holds BloomFilter's options
@@ -35,9 +39,13 @@ type BloomFilter struct {
 	k      uint64
 	b      *bitmap.Bitmap
 	locker sync.Locker
+	// legacy is true for the BloomFilter loaded from the data written before double hashing was used,
+	// it keeps using hash.GenHashInts so that the values added before are still found
+	legacy bool
 }
 
-// New new a BloomFilter with m bits and k hash functions
+// New new a BloomFilter with m bits and k hash functions.
+// The k bit positions of a value are derived by double hashing from two FNV hashes of the value.
 func New(m, k uint64, opts ...Option) *BloomFilter {
 	opt := Options{
 		locker: defaultLocker,
@@ -75,6 +83,8 @@ func NewFromData(data []byte, opts ...Option) *BloomFilter {
 	reader := bytes.NewReader(data)
 	binary.Read(reader, binary.LittleEndian, &b.m)
 	binary.Read(reader, binary.LittleEndian, &b.k)
+	b.legacy = b.k&doubleHashing == 0
+	b.k &^= doubleHashing
 	b.b = bitmap.NewFromData(data[8+8:])
 	return b
 }
@@ -88,27 +98,94 @@ func EstimateParameters(n uint64, p float64) (m uint64, k uint64) {
 
 // Add add a value to the BloomFilter
 func (bf *BloomFilter) Add(val string) {
+	bf.AddBytes([]byte(val))
+}
+
+// AddBytes add a value to the BloomFilter, AddBytes([]byte(val)) is the same as Add(val)
+func (bf *BloomFilter) AddBytes(data []byte) {
 	bf.locker.Lock()
 	defer bf.locker.Unlock()
 
-	hashs := hash.GenHashInts([]byte(salt+val), int(bf.k))
-	for i := uint64(0); i < bf.k; i++ {
-		bf.b.Set(hashs[i] % bf.m)
-	}
+	bf.positions(data, func(position uint64) bool {
+		bf.b.Set(position)
+		return true
+	})
 }
 
 // Contains returns true if value passed is (high probability) in the BloomFilter, or false if not.
 func (bf *BloomFilter) Contains(val string) bool {
+	return bf.ContainsBytes([]byte(val))
+}
+
+// ContainsBytes returns true if value passed is (high probability) in the BloomFilter, or false if not.
+// ContainsBytes([]byte(val)) is the same as Contains(val)
+func (bf *BloomFilter) ContainsBytes(data []byte) bool {
 	bf.locker.RLock()
 	defer bf.locker.RUnlock()
 
-	hashs := hash.GenHashInts([]byte(salt+val), int(bf.k))
+	found := true
+	bf.positions(data, func(position uint64) bool {
+		found = bf.b.IsSet(position)
+		return found
+	})
+	return found
+}
+
+// positions calls fn with the k bit positions of data until fn returns false.
+// The i-th position is (h1 + i*h2) mod m, where h1 and h2 are the FNV-1a and FNV-1 hashes of data.
+// The FNV hashes of similar values are close, so they are mixed by fmix64 before being used.
+func (bf *BloomFilter) positions(data []byte, fn func(position uint64) bool) {
+	if bf.legacy {
+		hashs := hash.GenHashInts(saltedBytes(data), int(bf.k))
+		for i := uint64(0); i < bf.k; i++ {
+			if !fn(hashs[i] % bf.m) {
+				return
+			}
+		}
+		return
+	}
+	h1, h2 := fnv.New64a(), fnv.New64()
+	h1.Write(data)
+	h2.Write(data)
+	position, step := fmix64(h1.Sum64())%bf.m, fmix64(h2.Sum64())%bf.m
+	if step == 0 {
+		step = 1
+	}
 	for i := uint64(0); i < bf.k; i++ {
-		if !bf.b.IsSet(hashs[i] % bf.m) {
-			return false
+		if !fn(position) {
+			return
 		}
+		position = (position + step) % bf.m
 	}
-	return true
+}
+
+// fmix64 is the finalizer of MurmurHash3, it spreads every bit of h over all the bits of the result
+func fmix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb3f98a1a3ee5
+	h ^= h >> 33
+	return h
+}
+
+func saltedBytes(data []byte) []byte {
+	salted := make([]byte, 0, len(salt)+len(data))
+	salted = append(salted, salt...)
+	return append(salted, data...)
+}
+
+// EstimatedFalsePositiveRate returns the probability that Contains returns true for a value never added,
+// estimated from the fraction of bits set in the BloomFilter
+func (bf *BloomFilter) EstimatedFalsePositiveRate() float64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	if bf.m == 0 {
+		return 0
+	}
+	fill := float64(bf.b.Count()) / float64(bf.m)
+	return math.Pow(fill, float64(bf.k))
 }
 
 // Data returns the data of BloomFilter, it can bee used to new a BloomFilter by using function 'NewFromData' .
@@ -118,7 +195,11 @@ func (bf *BloomFilter) Data() []byte {
 
 	buf := new(bytes.Buffer)
 	binary.Write(buf, binary.LittleEndian, bf.m)
-	binary.Write(buf, binary.LittleEndian, bf.k)
+	k := bf.k
+	if !bf.legacy {
+		k |= doubleHashing
+	}
+	binary.Write(buf, binary.LittleEndian, k)
 	buf.Write(bf.b.Data())
 	return buf.Bytes()
 }
@@ -1,6 +1,7 @@
 package bloom
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -20,3 +21,73 @@ func TestBoomfilter(t *testing.T) {
 	b.Add("bbbbb")
 	assert.True(t, b.Contains("bbbbb"))
 }
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	const n = 10000
+	const fp = 0.01
+	b := NewWithEstimates(n, fp)
+	assert.Equal(t, 0.0, b.EstimatedFalsePositiveRate())
+
+	for i := 0; i < n; i++ {
+		b.AddBytes([]byte(fmt.Sprintf("item-%d", i)))
+	}
+	// no false negatives
+	for i := 0; i < n; i++ {
+		assert.True(t, b.ContainsBytes([]byte(fmt.Sprintf("item-%d", i))))
+		assert.True(t, b.Contains(fmt.Sprintf("item-%d", i)))
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if b.ContainsBytes([]byte(fmt.Sprintf("other-%d", i))) {
+			falsePositives++
+		}
+	}
+	rate := float64(falsePositives) / n
+	assert.True(t, rate < fp*2, "empirical false positive rate %v", rate)
+
+	estimated := b.EstimatedFalsePositiveRate()
+	assert.True(t, estimated > fp/2 && estimated < fp*2, "estimated false positive rate %v", estimated)
+}
+
+func TestBloomFilterManyHashFunctions(t *testing.T) {
+	const n = 10000
+	const fp = 0.0001
+	b := NewWithEstimates(n, fp)
+	assert.True(t, b.k > 8)
+
+	for i := 0; i < n; i++ {
+		b.Add(fmt.Sprintf("item-%d", i))
+	}
+	for i := 0; i < n; i++ {
+		assert.True(t, b.Contains(fmt.Sprintf("item-%d", i)))
+	}
+
+	const tries = 100 * n
+	falsePositives := 0
+	for i := 0; i < tries; i++ {
+		if b.Contains(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	rate := float64(falsePositives) / tries
+	assert.True(t, rate < fp*2, "empirical false positive rate %v", rate)
+}
+
+func TestBloomFilterLegacyData(t *testing.T) {
+	// a BloomFilter loaded from the data written before double hashing keeps its hash functions
+	legacy := New(10000, 7)
+	legacy.legacy = true
+	legacy.Add("aa")
+	data := legacy.Data()
+
+	b := NewFromData(data)
+	assert.True(t, b.legacy)
+	assert.Equal(t, uint64(7), b.k)
+	assert.True(t, b.Contains("aa"))
+	assert.Equal(t, data, b.Data())
+
+	b = NewFromData(New(10000, 7).Data())
+	assert.False(t, b.legacy)
+	assert.Equal(t, uint64(7), b.k)
+}
@@ -0,0 +1,192 @@
+package concurrentmap
+
+import (
+	"fmt"
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+	"hash/fnv"
+	"math"
+)
+
+var (
+	defaultKeyComparator = comparator.BuiltinTypeComparator
+	defaultShardCount    = 32
+)
+
+// Hasher is a function which returns the hash of key, equal keys must have equal hashes
+type Hasher func(key interface{}) uint64
+
+// Options holds ConcurrentMap's options
+type Options struct {
+	keyCmp     comparator.Comparator
+	hasher     Hasher
+	shardCount int
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithKeyComparator sets Key comparator option
+func WithKeyComparator(cmp comparator.Comparator) Option {
+	return func(option *Options) {
+		option.keyCmp = cmp
+	}
+}
+
+// WithHasher sets the function used to distribute keys among the shards
+func WithHasher(hasher Hasher) Option {
+	return func(option *Options) {
+		option.hasher = hasher
+	}
+}
+
+// WithShardCount sets the number of shards, the default is 32
+func WithShardCount(count int) Option {
+	return func(option *Options) {
+		option.shardCount = count
+	}
+}
+
+// ConcurrentMap is a goroutine-safe map which distributes keys among several Maps (shards) by their hashes.
+// Every shard has its own lock, so that operations on keys in different shards can proceed in parallel.
+// The keys are ordered within a shard, but not across shards.
+type ConcurrentMap struct {
+	shards []*treemap.Map
+	hasher Hasher
+}
+
+// New creates a ConcurrentMap
+func New(opts ...Option) *ConcurrentMap {
+	option := Options{
+		keyCmp:     defaultKeyComparator,
+		hasher:     DefaultHasher,
+		shardCount: defaultShardCount,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	if option.shardCount <= 0 {
+		option.shardCount = 1
+	}
+	cm := &ConcurrentMap{
+		shards: make([]*treemap.Map, option.shardCount),
+		hasher: option.hasher,
+	}
+	for i := range cm.shards {
+		cm.shards[i] = treemap.New(treemap.WithKeyComparator(option.keyCmp), treemap.WithGoroutineSafe())
+	}
+	return cm
+}
+
+func (cm *ConcurrentMap) shard(key interface{}) *treemap.Map {
+	return cm.shards[cm.hasher(key)%uint64(len(cm.shards))]
+}
+
+// Insert inserts a key-value to the ConcurrentMap, the value will be replaced if key exists
+func (cm *ConcurrentMap) Insert(key, value interface{}) {
+	cm.shard(key).Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or nil and false if not found
+func (cm *ConcurrentMap) Get(key interface{}) (interface{}, bool) {
+	return cm.shard(key).Lookup(key)
+}
+
+// Erase erases key from the ConcurrentMap
+func (cm *ConcurrentMap) Erase(key interface{}) {
+	cm.shard(key).Erase(key)
+}
+
+// Contains returns true if key in the ConcurrentMap, otherwise returns false
+func (cm *ConcurrentMap) Contains(key interface{}) bool {
+	return cm.shard(key).Contains(key)
+}
+
+// Size returns the number of elements in the ConcurrentMap. The shards are counted one by one,
+// so the result may not reflect a single point in time if other goroutines are modifying the ConcurrentMap.
+func (cm *ConcurrentMap) Size() int {
+	size := 0
+	for _, shard := range cm.shards {
+		size += shard.Size()
+	}
+	return size
+}
+
+// ForEach calls fn for every element in the ConcurrentMap shard by shard, the elements are only ordered within a shard.
+// Note that a shard is read-locked while it is visited, so fn must not modify the ConcurrentMap.
+func (cm *ConcurrentMap) ForEach(fn func(key, value interface{})) {
+	for _, shard := range cm.shards {
+		shard.ForEach(fn)
+	}
+}
+
+// Clear clears the ConcurrentMap shard by shard
+func (cm *ConcurrentMap) Clear() {
+	for _, shard := range cm.shards {
+		shard.Clear()
+	}
+}
+
+// DefaultHasher hashes builtin integers, floats, strings and bools directly, and other keys by their fmt.Sprint result
+func DefaultHasher(key interface{}) uint64 {
+	switch k := key.(type) {
+	case int:
+		return mix(uint64(k))
+	case int8:
+		return mix(uint64(k))
+	case int16:
+		return mix(uint64(k))
+	case int32:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case uint:
+		return mix(uint64(k))
+	case uint8:
+		return mix(uint64(k))
+	case uint16:
+		return mix(uint64(k))
+	case uint32:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	case uintptr:
+		return mix(uint64(k))
+	case float32:
+		return hashFloat(float64(k))
+	case float64:
+		return hashFloat(k)
+	case bool:
+		if k {
+			return 1
+		}
+		return 0
+	case string:
+		return hashString(k)
+	}
+	return hashString(fmt.Sprint(key))
+}
+
+// hashFloat hashes -0.0 and +0.0 the same, as they are equal by the comparators
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return mix(math.Float64bits(f))
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix scrambles the bits of x, so that sequential integers spread evenly among the shards
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
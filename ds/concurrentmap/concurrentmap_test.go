@@ -0,0 +1,131 @@
+package concurrentmap
+
+import (
+	"fmt"
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	m := New(WithShardCount(4))
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+
+	for i := 0; i < 2000; i++ {
+		m.Insert(i, i*2)
+	}
+	assert.Equal(t, 2000, m.Size())
+	value, ok := m.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, 20, value)
+
+	m.Erase(10)
+	assert.False(t, m.Contains(10))
+	assert.True(t, m.Contains(11))
+
+	sum := 0
+	m.ForEach(func(key, value interface{}) {
+		sum += value.(int) - key.(int)*2
+	})
+	assert.Equal(t, 0, sum)
+
+	// keys are spread among all shards
+	for _, shard := range m.shards {
+		assert.True(t, shard.Size() > 300)
+	}
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+
+	s := New()
+	for i := 0; i < 100; i++ {
+		s.Insert(fmt.Sprint(i), i)
+	}
+	value, _ = s.Get("42")
+	assert.Equal(t, 42, value)
+	assert.Equal(t, DefaultHasher("42"), DefaultHasher("42"))
+	assert.Equal(t, DefaultHasher(struct{ a int }{1}), DefaultHasher(struct{ a int }{1}))
+}
+
+func TestConcurrentMapGoroutineSafe(t *testing.T) {
+	m := New()
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Insert(g*1000+i, i)
+				m.Get(i)
+				if i%2 == 0 {
+					m.Erase(g*1000 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 4000, m.Size())
+}
+
+func TestConcurrentMapGetWhileInsert(t *testing.T) {
+	// run with -race: Get must read the value under the lock of the shard
+	m := New()
+	m.Insert(1, 0)
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			m.Insert(1, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			value, ok := m.Get(1)
+			assert.True(t, ok)
+			assert.True(t, value.(int) >= 0)
+		}
+	}()
+	wg.Wait()
+	value, _ := m.Get(1)
+	assert.Equal(t, 999, value)
+}
+
+func TestDefaultHasherZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	assert.Equal(t, DefaultHasher(0.0), DefaultHasher(negZero))
+	assert.Equal(t, DefaultHasher(float32(0)), DefaultHasher(float32(negZero)))
+
+	m := New()
+	m.Insert(0.0, "a")
+	value, ok := m.Get(negZero)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+}
+
+func benchmarkParallelInsert(b *testing.B, insert func(key, value interface{})) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			insert(i%100000, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkParallelInsertMap(b *testing.B) {
+	m := treemap.New(treemap.WithGoroutineSafe())
+	benchmarkParallelInsert(b, m.Insert)
+}
+
+func BenchmarkParallelInsertConcurrentMap(b *testing.B) {
+	for _, shards := range []int{1, 4, 32} {
+		b.Run(fmt.Sprintf("shards-%d", shards), func(b *testing.B) {
+			m := New(WithShardCount(shards))
+			benchmarkParallelInsert(b, m.Insert)
+		})
+	}
+}
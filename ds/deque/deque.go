@@ -3,6 +3,8 @@ package deque
 import (
 	"errors"
 	"fmt"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
 )
 
 // Constants definition
@@ -15,31 +17,102 @@ var (
 	ErrOutOffRange = errors.New("out off range")
 )
 
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds Deque's options
+type Options struct {
+	locker       sync.Locker
+	capacity     int
+	growthFactor float64
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets Deque goroutine-safety,
+// Note that iterators are not goroutine safe, so don't use iterators in multi goroutines
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithCapacity preallocates space for capacity values, so that no allocation is needed until the Deque holds more values than that
+func WithCapacity(capacity int) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// WithGrowthFactor sets the factor by which the Deque's segment table grows when it is full, the default is 2.
+// A factor not greater than 1 is ignored.
+func WithGrowthFactor(factor float64) Option {
+	return func(option *Options) {
+		option.growthFactor = factor
+	}
+}
+
 // Deque supports efficient data insertion from the head and tail, random access and iterator access.
 type Deque struct {
-	pool  *Pool
-	segs  []*Segment
-	begin int
-	end   int
-	size  int
+	pool         *Pool
+	segs         []*Segment
+	begin        int
+	end          int
+	size         int
+	growthFactor float64
+	locker       sync.Locker
 }
 
 // New news a deque
-func New() *Deque {
+func New(opts ...Option) *Deque {
+	option := Options{
+		locker:       defaultLocker,
+		growthFactor: 2,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	if option.growthFactor <= 1 {
+		option.growthFactor = 2
+	}
 	dq := &Deque{
-		pool: newPool(),
-		segs: make([]*Segment, 0),
+		pool:         newPool(),
+		segs:         make([]*Segment, 0),
+		growthFactor: option.growthFactor,
+		locker:       option.locker,
+	}
+	if option.capacity > 0 {
+		// the values may start in the middle of the first segment, so one more segment is needed,
+		// and the segment table is expanded as soon as it is full, so one more slot is needed
+		n := (option.capacity+SegmentCapacity-1)/SegmentCapacity + 1
+		dq.segs = make([]*Segment, n+1)
+		dq.pool.segs = make([]*Segment, 0, n)
+		// allocate all segments at once
+		segs := make([]Segment, n)
+		data := make([]interface{}, n*SegmentCapacity)
+		for i := range segs {
+			segs[i].data = data[i*SegmentCapacity : (i+1)*SegmentCapacity : (i+1)*SegmentCapacity]
+			dq.pool.put(&segs[i])
+		}
 	}
 	return dq
 }
 
 // Size returns the size of deque
 func (d *Deque) Size() int {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
 	return d.size
 }
 
 // Empty returns true if the Deque is empty,otherwise returns false.
 func (d *Deque) Empty() bool {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
 	return d.size == 0
 }
 
@@ -55,6 +128,13 @@ func (d *Deque) segUsed() int {
 
 // PushFront pushed value to the front of d
 func (d *Deque) PushFront(value interface{}) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	d.pushFront(value)
+}
+
+func (d *Deque) pushFront(value interface{}) {
 	d.firstAvailableSegment().pushFront(value)
 	d.size++
 	if d.segUsed() >= len(d.segs) {
@@ -64,6 +144,13 @@ func (d *Deque) PushFront(value interface{}) {
 
 // PushBack pushed value to the back of d
 func (d *Deque) PushBack(value interface{}) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	d.pushBack(value)
+}
+
+func (d *Deque) pushBack(value interface{}) {
 	d.lastAvailableSegment().pushBack(value)
 	d.size++
 	if d.segUsed() >= len(d.segs) {
@@ -73,15 +160,18 @@ func (d *Deque) PushBack(value interface{}) {
 
 // Insert inserts value to the position of d
 func (d *Deque) Insert(position int, value interface{}) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
 	if position < 0 || position > d.size {
 		return
 	}
 	if position == 0 {
-		d.PushFront(value)
+		d.pushFront(value)
 		return
 	}
 	if position == d.size {
-		d.PushBack(value)
+		d.pushBack(value)
 		return
 	}
 	seg, pos := d.pos(position)
@@ -148,17 +238,30 @@ func (d *Deque) moveBackInsert(seg, pos int, value interface{}) {
 
 // Front returns the front value of d
 func (d *Deque) Front() interface{} {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
 	return d.firstSegment().front()
 }
 
 // Back returns the back value of d
 func (d *Deque) Back() interface{} {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
 	return d.lastSegment().back()
 }
 
 // At returns the value of d at position
 func (d *Deque) At(position int) interface{} {
-	if position < 0 || position >= d.Size() {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
+	return d.at(position)
+}
+
+func (d *Deque) at(position int) interface{} {
+	if position < 0 || position >= d.size {
 		return nil
 	}
 	seg, pos := d.pos(position)
@@ -167,6 +270,13 @@ func (d *Deque) At(position int) interface{} {
 
 // Set sets the value of d at position
 func (d *Deque) Set(position int, val interface{}) error {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	return d.set(position, val)
+}
+
+func (d *Deque) set(position int, val interface{}) error {
 	if position < 0 || position >= d.size {
 		return ErrOutOffRange
 	}
@@ -177,6 +287,13 @@ func (d *Deque) Set(position int, val interface{}) error {
 
 // PopFront returns the font value fo d, and removes it
 func (d *Deque) PopFront() interface{} {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	return d.popFront()
+}
+
+func (d *Deque) popFront() interface{} {
 	if d.size == 0 {
 		return nil
 	}
@@ -194,6 +311,13 @@ func (d *Deque) PopFront() interface{} {
 
 // PopBack returns the back value fo d, and removes it
 func (d *Deque) PopBack() interface{} {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	return d.popBack()
+}
+
+func (d *Deque) popBack() interface{} {
 	if d.size == 0 {
 		return nil
 	}
@@ -214,6 +338,9 @@ func (d *Deque) PopBack() interface{} {
 
 // EraseAt erases the item at position
 func (d *Deque) EraseAt(position int) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
 	if position < 0 || position >= d.size {
 		return
 	}
@@ -249,6 +376,13 @@ func (d *Deque) EraseAt(position int) {
 
 // EraseRange erases items in range [firstPos, lastPos)
 func (d *Deque) EraseRange(firstPos, lastPos int) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	d.eraseRange(firstPos, lastPos)
+}
+
+func (d *Deque) eraseRange(firstPos, lastPos int) {
 	if firstPos < 0 || firstPos >= lastPos || lastPos > d.size {
 		return
 	}
@@ -256,25 +390,28 @@ func (d *Deque) EraseRange(firstPos, lastPos int) {
 	if d.size-firstPos < lastPos {
 		// move back
 		for pos := firstPos; pos+num < d.size; pos++ {
-			d.Set(pos, d.At(pos+num))
+			d.set(pos, d.at(pos+num))
 		}
 		for ; num > 0; num-- {
-			d.PopBack()
+			d.popBack()
 		}
 	} else {
 		// move front
 		for pos := lastPos - 1; pos-num >= 0; pos-- {
-			d.Set(pos, d.At(pos-num))
+			d.set(pos, d.at(pos-num))
 		}
 		for ; num > 0; num-- {
-			d.PopFront()
+			d.popFront()
 		}
 	}
 }
 
 // Clear erases all elements in deque
 func (d *Deque) Clear() {
-	d.EraseRange(0, d.size)
+	d.locker.Lock()
+	defer d.locker.Unlock()
+
+	d.eraseRange(0, d.size)
 }
 
 func (d *Deque) putToPool(s *Segment) {
@@ -346,9 +483,9 @@ func (d *Deque) pos(position int) (int, int) {
 }
 
 func (d *Deque) expand() {
-	newCapacity := d.segUsed() * 2
-	if newCapacity == 0 {
-		newCapacity = 1
+	newCapacity := int(float64(d.segUsed()) * d.growthFactor)
+	if newCapacity <= d.segUsed() {
+		newCapacity = d.segUsed() + 1
 	}
 	seg := make([]*Segment, newCapacity)
 	for i := 0; i < d.segUsed(); i++ {
@@ -386,12 +523,15 @@ func (d *Deque) preIndex(index int) int {
 
 // String returns d in string format
 func (d *Deque) String() string {
+	d.locker.RLock()
+	defer d.locker.RUnlock()
+
 	str := "["
-	for i := 0; i < d.Size(); i++ {
+	for i := 0; i < d.size; i++ {
 		if str != "[" {
 			str += " "
 		}
-		str += fmt.Sprintf("%v", d.At(i))
+		str += fmt.Sprintf("%v", d.at(i))
 	}
 	str += "]"
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 )
@@ -244,3 +245,123 @@ func TestInsert2(t *testing.T) {
 	q.Insert(3, 5)
 	assert.Equal(t, "[4 0 1 5 2 3]", q.String())
 }
+
+func TestDequeWrapAround(t *testing.T) {
+	q := New()
+	var ref []int
+	for i := 0; i < 20000; i++ {
+		switch rand.Intn(5) {
+		case 0, 1:
+			q.PushBack(i)
+			ref = append(ref, i)
+		case 2:
+			q.PushFront(i)
+			ref = append([]int{i}, ref...)
+		case 3:
+			if len(ref) > 0 {
+				assert.Equal(t, ref[0], q.PopFront())
+				ref = ref[1:]
+			}
+		case 4:
+			if len(ref) > 0 {
+				assert.Equal(t, ref[len(ref)-1], q.PopBack())
+				ref = ref[:len(ref)-1]
+			}
+		}
+	}
+	assert.Equal(t, len(ref), q.Size())
+	for i, v := range ref {
+		assert.Equal(t, v, q.At(i))
+	}
+	i := 0
+	for iter := q.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, ref[i], iter.Value())
+		i++
+	}
+	assert.Equal(t, len(ref), i)
+}
+
+func TestDequeGoroutineSafe(t *testing.T) {
+	q := New(WithGoroutineSafe())
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if j%2 == 0 {
+					q.PushBack(i)
+				} else {
+					q.PushFront(i)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10000, q.Size())
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				if j%2 == 0 {
+					assert.NotNil(t, q.PopBack())
+				} else {
+					assert.NotNil(t, q.PopFront())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5000, q.Size())
+	q.Clear()
+	assert.True(t, q.Empty())
+}
+
+func TestDequeCapacity(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithCapacity(1000)},
+		{WithCapacity(1)},
+		{WithGrowthFactor(1.5)},
+		{WithGrowthFactor(0.5)},
+		{WithCapacity(300), WithGrowthFactor(3)},
+	} {
+		q := New(opts...)
+		for i := 0; i < 2000; i++ {
+			if i%2 == 0 {
+				q.PushBack(i)
+			} else {
+				q.PushFront(i)
+			}
+		}
+		assert.Equal(t, 2000, q.Size())
+		assert.Equal(t, 1999, q.Front())
+		assert.Equal(t, 1998, q.Back())
+		for i := 1999; i >= 0; i -= 2 {
+			assert.Equal(t, i, q.PopFront())
+		}
+		for i := 1998; i >= 0; i -= 2 {
+			assert.Equal(t, i, q.PopBack())
+		}
+		assert.True(t, q.Empty())
+	}
+}
+
+func benchmarkDequePushBack(b *testing.B, opts ...Option) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q := New(opts...)
+		for j := 0; j < 10000; j++ {
+			q.PushBack(j % 256) // small integers don't allocate when converted to interface{}
+		}
+	}
+}
+
+func BenchmarkDequePushBack(b *testing.B) {
+	benchmarkDequePushBack(b)
+}
+
+func BenchmarkDequePushBackWithCapacity(b *testing.B) {
+	benchmarkDequePushBack(b, WithCapacity(10000))
+}
@@ -0,0 +1,322 @@
+package fibheap
+
+import (
+	"errors"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+	"unsafe"
+)
+
+var (
+	defaultComparator = comparator.BuiltinTypeComparator
+	defaultLocker     sync.FakeLocker
+)
+
+// ErrGreaterKey is returned by DecreaseKey when the new key is greater than the current key of the node
+var ErrGreaterKey = errors.New("new key is greater than current key")
+
+// Options holds FibHeap's options
+type Options struct {
+	cmp    comparator.Comparator
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithComparator sets the comparator option
+func WithComparator(cmp comparator.Comparator) Option {
+	return func(option *Options) {
+		option.cmp = cmp
+	}
+}
+
+// WithGoroutineSafe sets the GoroutineSafe option
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// Node is a node of FibHeap, it is returned by Insert as a handle to decrease its key later
+type Node struct {
+	key    interface{}
+	value  interface{}
+	parent *Node
+	child  *Node
+	left   *Node
+	right  *Node
+	degree int
+	mark   bool
+}
+
+// Key returns the key of the node
+func (n *Node) Key() interface{} {
+	return n.key
+}
+
+// Value returns the value of the node
+func (n *Node) Value() interface{} {
+	return n.value
+}
+
+// FibHeap is a Fibonacci heap, a min-heap which supports decreasing the key of a node and merging two heaps efficiently.
+// Insert, Min, DecreaseKey and Merge take O(1) amortized time, and ExtractMin takes O(log n) amortized time.
+type FibHeap struct {
+	min    *Node
+	size   int
+	cmp    comparator.Comparator
+	locker sync.Locker
+}
+
+// New creates a new FibHeap
+func New(opts ...Option) *FibHeap {
+	option := Options{
+		cmp:    defaultComparator,
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &FibHeap{
+		cmp:    option.cmp,
+		locker: option.locker,
+	}
+}
+
+// Insert inserts key with value to the heap and returns its node
+func (h *FibHeap) Insert(key, value interface{}) *Node {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	n := &Node{key: key, value: value}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Min returns the node with the minimum key, or nil if the heap is empty
+func (h *FibHeap) Min() *Node {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	return h.min
+}
+
+// ExtractMin removes the node with the minimum key from the heap and returns it, or nil if the heap is empty
+func (h *FibHeap) ExtractMin() *Node {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	z := h.min
+	if z == nil {
+		return nil
+	}
+	for z.child != nil {
+		x := z.child
+		h.removeChild(z, x)
+		h.addRoot(x)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		remove(z)
+		h.consolidate()
+	}
+	h.size--
+	z.left, z.right = z, z
+	return z
+}
+
+// DecreaseKey decreases the key of node n to key, it returns ErrGreaterKey if key is greater than the current key.
+// n must be a node in the heap, i.e. it is returned by Insert of the heap or a heap merged into it, and it is not extracted.
+func (h *FibHeap) DecreaseKey(n *Node, key interface{}) error {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	if h.cmp(key, n.key) > 0 {
+		return ErrGreaterKey
+	}
+	n.key = key
+	p := n.parent
+	if p != nil && h.less(n, p) {
+		h.cut(n, p)
+		h.cascadingCut(p)
+	}
+	if h.less(n, h.min) {
+		h.min = n
+	}
+	return nil
+}
+
+// Merge moves all the nodes of other into h in O(1) time, other becomes empty.
+// The nodes of other remain valid handles in h. Both heaps should use the same comparator.
+func (h *FibHeap) Merge(other *FibHeap) {
+	if h == other {
+		return
+	}
+	a, b := h, other
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	if other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		// splice the two root lists
+		hRight, oLeft := h.min.right, other.min.left
+		h.min.right = other.min
+		other.min.left = h.min
+		oLeft.right = hRight
+		hRight.left = oLeft
+		if h.less(other.min, h.min) {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+	other.min = nil
+	other.size = 0
+}
+
+// Size returns the number of nodes in the heap
+func (h *FibHeap) Size() int {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	return h.size
+}
+
+// Empty returns true if the heap is empty, otherwise returns false
+func (h *FibHeap) Empty() bool {
+	return h.Size() == 0
+}
+
+// Clear removes all the nodes from the heap
+func (h *FibHeap) Clear() {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	h.min = nil
+	h.size = 0
+}
+
+func (h *FibHeap) less(a, b *Node) bool {
+	return h.cmp(a.key, b.key) < 0
+}
+
+// addRoot adds n to the root list
+func (h *FibHeap) addRoot(n *Node) {
+	n.parent = nil
+	n.mark = false
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+	insertAfter(h.min, n)
+	if h.less(n, h.min) {
+		h.min = n
+	}
+}
+
+// consolidate links the roots with the same degree until all the roots have different degrees, and finds the new min
+func (h *FibHeap) consolidate() {
+	var roots []*Node
+	for n := h.min; ; {
+		roots = append(roots, n)
+		n = n.right
+		if n == h.min {
+			break
+		}
+	}
+	var byDegree []*Node
+	for _, x := range roots {
+		d := x.degree
+		for d < len(byDegree) && byDegree[d] != nil {
+			y := byDegree[d]
+			if h.less(y, x) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+		for d >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[d] = x
+	}
+	h.min = nil
+	for _, n := range byDegree {
+		if n != nil {
+			remove(n)
+			h.addRoot(n)
+		}
+	}
+}
+
+// link removes root y from the root list and makes it a child of root x
+func (h *FibHeap) link(y, x *Node) {
+	remove(y)
+	y.parent = x
+	if x.child == nil {
+		x.child = y
+	} else {
+		insertAfter(x.child, y)
+	}
+	x.degree++
+	y.mark = false
+}
+
+// removeChild removes child x from the child list of p
+func (h *FibHeap) removeChild(p, x *Node) {
+	if x.right == x {
+		p.child = nil
+	} else if p.child == x {
+		p.child = x.right
+	}
+	remove(x)
+	p.degree--
+}
+
+// cut moves n from the child list of its parent p to the root list
+func (h *FibHeap) cut(n, p *Node) {
+	h.removeChild(p, n)
+	h.addRoot(n)
+}
+
+// cascadingCut cuts n from its parent if n has lost a child before, and then goes on with the parent
+func (h *FibHeap) cascadingCut(n *Node) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+		h.cut(n, p)
+	}
+}
+
+// insertAfter inserts n after a in a circular list
+func insertAfter(a, n *Node) {
+	n.left = a
+	n.right = a.right
+	a.right.left = n
+	a.right = n
+}
+
+// remove removes n from its circular list
+func remove(n *Node) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
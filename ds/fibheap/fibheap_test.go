@@ -0,0 +1,153 @@
+package fibheap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkHeapOrder checks every child has a key not less than its parent's and returns the number of nodes
+func checkHeapOrder(t *testing.T, h *FibHeap, first *Node, parent *Node) int {
+	if first == nil {
+		return 0
+	}
+	count := 0
+	n := first
+	for {
+		assert.True(t, n.parent == parent)
+		if parent != nil {
+			assert.False(t, h.less(n, parent))
+		} else {
+			assert.False(t, h.less(n, h.min))
+		}
+		count += 1 + checkHeapOrder(t, h, n.child, n)
+		n = n.right
+		if n == first {
+			break
+		}
+	}
+	return count
+}
+
+func TestFibHeap(t *testing.T) {
+	h := New()
+	assert.True(t, h.Empty())
+	assert.Nil(t, h.Min())
+	assert.Nil(t, h.ExtractMin())
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2, 7} {
+		h.Insert(v, v*10)
+	}
+	assert.Equal(t, 7, h.Size())
+	assert.Equal(t, 1, h.Min().Key())
+	assert.Equal(t, 10, h.Min().Value())
+
+	var keys []interface{}
+	for !h.Empty() {
+		keys = append(keys, h.ExtractMin().Key())
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 5, 7, 8, 9}, keys)
+}
+
+func TestFibHeapDecreaseKey(t *testing.T) {
+	h := New(WithGoroutineSafe())
+	nodes := make([]*Node, 0)
+	for i := 10; i < 20; i++ {
+		nodes = append(nodes, h.Insert(i, i))
+	}
+	// make the heap have children
+	assert.Equal(t, 10, h.ExtractMin().Key())
+
+	assert.Nil(t, h.DecreaseKey(nodes[9], 5))
+	assert.Equal(t, 5, h.Min().Key())
+	assert.Equal(t, 19, h.Min().Value())
+	assert.Equal(t, ErrGreaterKey, h.DecreaseKey(nodes[5], 100))
+	assert.Nil(t, h.DecreaseKey(nodes[5], 15))
+	assert.Nil(t, h.DecreaseKey(nodes[3], 6))
+
+	var keys []interface{}
+	for !h.Empty() {
+		keys = append(keys, h.ExtractMin().Key())
+	}
+	assert.Equal(t, []interface{}{5, 6, 11, 12, 14, 15, 16, 17, 18}, keys)
+}
+
+func TestFibHeapRandom(t *testing.T) {
+	h := New()
+	r := rand.New(rand.NewSource(1))
+	var nodes []*Node
+	ref := make(map[*Node]int)
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(10); {
+		case op < 5:
+			key := r.Intn(100000)
+			n := h.Insert(key, nil)
+			nodes = append(nodes, n)
+			ref[n] = key
+		case op < 8 && len(nodes) > 0:
+			n := nodes[r.Intn(len(nodes))]
+			key := ref[n] - r.Intn(1000)
+			assert.Nil(t, h.DecreaseKey(n, key))
+			ref[n] = key
+		case len(nodes) > 0:
+			n := h.ExtractMin()
+			for _, key := range ref {
+				assert.True(t, n.Key().(int) <= key)
+			}
+			assert.Equal(t, ref[n], n.Key())
+			delete(ref, n)
+			for j := range nodes {
+				if nodes[j] == n {
+					nodes = append(nodes[:j], nodes[j+1:]...)
+					break
+				}
+			}
+		}
+		if i%500 == 0 {
+			assert.Equal(t, len(ref), checkHeapOrder(t, h, h.min, nil))
+		}
+	}
+	assert.Equal(t, len(ref), h.Size())
+
+	expected := make([]int, 0, len(ref))
+	for _, key := range ref {
+		expected = append(expected, key)
+	}
+	sort.Ints(expected)
+	actual := make([]int, 0, len(ref))
+	for !h.Empty() {
+		actual = append(actual, h.ExtractMin().Key().(int))
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestFibHeapMerge(t *testing.T) {
+	a := New()
+	b := New()
+	na := a.Insert(3, "a")
+	b.Insert(1, "b")
+	b.Insert(4, "b")
+	a.Merge(New())
+	a.Merge(a)
+
+	a.Merge(b)
+	assert.Equal(t, 3, a.Size())
+	assert.True(t, b.Empty())
+	assert.Equal(t, 1, a.Min().Key())
+
+	// the handles remain valid after merging
+	assert.Nil(t, a.DecreaseKey(na, 0))
+	assert.Equal(t, "a", a.ExtractMin().Value())
+
+	c := New()
+	c.Merge(a)
+	assert.Equal(t, 1, c.ExtractMin().Key())
+	assert.Equal(t, 4, c.ExtractMin().Key())
+	assert.True(t, c.Empty())
+
+	c.Insert(1, nil)
+	c.Clear()
+	assert.True(t, c.Empty())
+	assert.Nil(t, c.Min())
+}
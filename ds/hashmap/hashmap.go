@@ -0,0 +1,157 @@
+package hashmap
+
+import (
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds HashMap's options
+type Options struct {
+	capacity int
+	locker   sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets HashMap goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithCapacity sets the initial capacity of HashMap
+func WithCapacity(capacity int) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// HashMap is an unordered map backed by the builtin map, keys must be comparable.
+// It provides the same methods as treemap.Map except the ordered ones.
+type HashMap struct {
+	m      map[interface{}]interface{}
+	locker sync.Locker
+}
+
+// New creates a new HashMap
+func New(opts ...Option) *HashMap {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &HashMap{
+		m:      make(map[interface{}]interface{}, option.capacity),
+		locker: option.locker,
+	}
+}
+
+// Insert inserts a key-value to the HashMap, the value will be replaced if key exists
+func (hm *HashMap) Insert(key, value interface{}) {
+	hm.locker.Lock()
+	defer hm.locker.Unlock()
+
+	hm.m[key] = value
+}
+
+// Get returns the value by key and true if found, or nil and false if not found
+func (hm *HashMap) Get(key interface{}) (interface{}, bool) {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	value, ok := hm.m[key]
+	return value, ok
+}
+
+// Erase erases key from the HashMap
+func (hm *HashMap) Erase(key interface{}) {
+	hm.locker.Lock()
+	defer hm.locker.Unlock()
+
+	delete(hm.m, key)
+}
+
+// Contains returns true if key in the HashMap, otherwise returns false
+func (hm *HashMap) Contains(key interface{}) bool {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	_, ok := hm.m[key]
+	return ok
+}
+
+// Size returns the number of elements in the HashMap
+func (hm *HashMap) Size() int {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	return len(hm.m)
+}
+
+// IsEmpty returns true if the HashMap contains no elements
+func (hm *HashMap) IsEmpty() bool {
+	return hm.Size() == 0
+}
+
+// Clear clears the HashMap
+func (hm *HashMap) Clear() {
+	hm.locker.Lock()
+	defer hm.locker.Unlock()
+
+	hm.m = make(map[interface{}]interface{})
+}
+
+// Keys returns all keys in the HashMap in unspecified order
+func (hm *HashMap) Keys() []interface{} {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	keys := make([]interface{}, 0, len(hm.m))
+	for key := range hm.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns all values in the HashMap in unspecified order
+func (hm *HashMap) Values() []interface{} {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	values := make([]interface{}, 0, len(hm.m))
+	for _, value := range hm.m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// ForEach calls fn for every element in the HashMap in unspecified order.
+// Note that the HashMap is read-locked during the iteration, so fn must not modify the HashMap.
+func (hm *HashMap) ForEach(fn func(key, value interface{})) {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	for key, value := range hm.m {
+		fn(key, value)
+	}
+}
+
+// Traversal traversals elements in the HashMap in unspecified order, it will not stop until to the end or visitor returns false
+func (hm *HashMap) Traversal(visitor visitor.KvVisitor) {
+	hm.locker.RLock()
+	defer hm.locker.RUnlock()
+
+	for key, value := range hm.m {
+		if !visitor(key, value) {
+			break
+		}
+	}
+}
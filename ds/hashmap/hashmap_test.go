@@ -0,0 +1,90 @@
+package hashmap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestHashMap(t *testing.T) {
+	m := New()
+	assert.True(t, m.IsEmpty())
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+
+	m.Insert(1, nil)
+	value, ok := m.Get(1)
+	assert.True(t, ok)
+	assert.Nil(t, value)
+
+	m.Insert(1, "a")
+	m.Insert("1", "b")
+	assert.Equal(t, 2, m.Size())
+	value, _ = m.Get(1)
+	assert.Equal(t, "a", value)
+	value, _ = m.Get("1")
+	assert.Equal(t, "b", value)
+
+	m.Erase(1)
+	assert.False(t, m.Contains(1))
+	assert.True(t, m.Contains("1"))
+	assert.Equal(t, []interface{}{"b"}, m.Values())
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestHashMapManyKeys(t *testing.T) {
+	m := New(WithCapacity(16))
+	for i := 0; i < 100000; i++ {
+		m.Insert(i, i*2)
+	}
+	assert.Equal(t, 100000, m.Size())
+	for i := 0; i < 100000; i += 7 {
+		value, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i*2, value)
+	}
+
+	keys := make([]int, 0)
+	for _, key := range m.Keys() {
+		keys = append(keys, key.(int))
+	}
+	sort.Ints(keys)
+	assert.Equal(t, 100000, len(keys))
+	assert.Equal(t, 99999, keys[len(keys)-1])
+
+	sum := 0
+	m.ForEach(func(key, value interface{}) {
+		sum += value.(int) - key.(int)*2
+	})
+	assert.Equal(t, 0, sum)
+
+	n := 0
+	m.Traversal(func(key, value interface{}) bool {
+		n++
+		return n < 10
+	})
+	assert.Equal(t, 10, n)
+}
+
+func TestHashMapGoroutineSafe(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Insert(g*1000+i, i)
+				m.Get(i)
+				m.Contains(i)
+				if i%2 == 0 {
+					m.Erase(g*1000 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 4000, m.Size())
+}
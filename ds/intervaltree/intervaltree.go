@@ -0,0 +1,429 @@
+package intervaltree
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+var (
+	defaultKeyComparator = comparator.BuiltinTypeComparator
+	defaultLocker        sync.FakeLocker
+)
+
+// Options holds IntervalTree's options
+type Options struct {
+	keyCmp comparator.Comparator
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithKeyComparator sets the comparator of the interval endpoints
+func WithKeyComparator(cmp comparator.Comparator) Option {
+	return func(option *Options) {
+		option.keyCmp = cmp
+	}
+}
+
+// WithGoroutineSafe sets IntervalTree goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// Interval is a closed interval [Low, High] with a value
+type Interval struct {
+	Low   interface{}
+	High  interface{}
+	Value interface{}
+}
+
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+type node struct {
+	interval Interval
+	// max is the maximum High of the intervals in the subtree rooted at the node
+	max    interface{}
+	color  color
+	parent *node
+	left   *node
+	right  *node
+}
+
+// IntervalTree is a red-black tree keyed by the low endpoints of intervals, each node is augmented with the maximum
+// high endpoint in its subtree, so that the intervals overlapping a given one can be found without visiting all of them.
+// Intervals are closed, so the intervals touching at an endpoint overlap. Duplicate intervals are allowed.
+type IntervalTree struct {
+	root   *node
+	size   int
+	keyCmp comparator.Comparator
+	locker sync.Locker
+}
+
+// New creates a new IntervalTree
+func New(opts ...Option) *IntervalTree {
+	option := Options{
+		keyCmp: defaultKeyComparator,
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &IntervalTree{
+		keyCmp: option.keyCmp,
+		locker: option.locker,
+	}
+}
+
+// Insert inserts the interval [low, high] with value to the IntervalTree, it panics if low > high
+func (t *IntervalTree) Insert(low, high, value interface{}) {
+	if t.keyCmp(low, high) > 0 {
+		panic("intervaltree: low is greater than high")
+	}
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	z := &node{interval: Interval{Low: low, High: high, Value: value}, max: high, color: red}
+	var y *node
+	for x := t.root; x != nil; {
+		y = x
+		if t.keyCmp(high, x.max) > 0 {
+			x.max = high
+		}
+		if t.less(z, x) {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	z.parent = y
+	t.size++
+	if y == nil {
+		z.color = black
+		t.root = z
+		return
+	} else if t.less(z, y) {
+		y.left = z
+	} else {
+		y.right = z
+	}
+	t.insertFixup(z)
+}
+
+// Remove removes an interval equal to [low, high] and returns true if found, otherwise returns false
+func (t *IntervalTree) Remove(low, high interface{}) bool {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	x := t.root
+	for x != nil {
+		c := t.keyCmp(low, x.interval.Low)
+		if c == 0 {
+			c = t.keyCmp(high, x.interval.High)
+		}
+		if c == 0 {
+			t.delete(x)
+			return true
+		}
+		if c < 0 {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	return false
+}
+
+// QueryOverlap returns all the intervals overlapping [low, high] in ascending order of their low endpoints,
+// it takes O(k log n) time where k is the number of the returned intervals
+func (t *IntervalTree) QueryOverlap(low, high interface{}) []Interval {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	intervals := make([]Interval, 0)
+	t.queryOverlap(t.root, low, high, &intervals)
+	return intervals
+}
+
+func (t *IntervalTree) queryOverlap(n *node, low, high interface{}, intervals *[]Interval) {
+	// no interval in the subtree ends at or after low
+	if n == nil || t.keyCmp(n.max, low) < 0 {
+		return
+	}
+	t.queryOverlap(n.left, low, high, intervals)
+	// the intervals of n and its right subtree all start after high
+	if t.keyCmp(n.interval.Low, high) > 0 {
+		return
+	}
+	if t.keyCmp(n.interval.High, low) >= 0 {
+		*intervals = append(*intervals, n.interval)
+	}
+	t.queryOverlap(n.right, low, high, intervals)
+}
+
+// Intervals returns all the intervals in ascending order of their low endpoints
+func (t *IntervalTree) Intervals() []Interval {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	intervals := make([]Interval, 0, t.size)
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		intervals = append(intervals, n.interval)
+		walk(n.right)
+	}
+	walk(t.root)
+	return intervals
+}
+
+// Size returns the number of intervals in the IntervalTree
+func (t *IntervalTree) Size() int {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	return t.size
+}
+
+// IsEmpty returns true if the IntervalTree is empty, otherwise returns false
+func (t *IntervalTree) IsEmpty() bool {
+	return t.Size() == 0
+}
+
+// Clear clears the IntervalTree
+func (t *IntervalTree) Clear() {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.root = nil
+	t.size = 0
+}
+
+// less orders the nodes by low endpoints, and then by high endpoints
+func (t *IntervalTree) less(a, b *node) bool {
+	c := t.keyCmp(a.interval.Low, b.interval.Low)
+	if c == 0 {
+		c = t.keyCmp(a.interval.High, b.interval.High)
+	}
+	return c < 0
+}
+
+// updateMax recomputes the max of n from its interval and children
+func (t *IntervalTree) updateMax(n *node) {
+	n.max = n.interval.High
+	if n.left != nil && t.keyCmp(n.left.max, n.max) > 0 {
+		n.max = n.left.max
+	}
+	if n.right != nil && t.keyCmp(n.right.max, n.max) > 0 {
+		n.max = n.right.max
+	}
+}
+
+func (t *IntervalTree) insertFixup(z *node) {
+	for z.parent != nil && z.parent.color == red {
+		if z.parent == z.parent.parent.left {
+			y := z.parent.parent.right
+			if y != nil && y.color == red {
+				z.parent.color = black
+				y.color = black
+				z.parent.parent.color = red
+				z = z.parent.parent
+			} else {
+				if z == z.parent.right {
+					z = z.parent
+					t.leftRotate(z)
+				}
+				z.parent.color = black
+				z.parent.parent.color = red
+				t.rightRotate(z.parent.parent)
+			}
+		} else {
+			y := z.parent.parent.left
+			if y != nil && y.color == red {
+				z.parent.color = black
+				y.color = black
+				z.parent.parent.color = red
+				z = z.parent.parent
+			} else {
+				if z == z.parent.left {
+					z = z.parent
+					t.rightRotate(z)
+				}
+				z.parent.color = black
+				z.parent.parent.color = red
+				t.leftRotate(z.parent.parent)
+			}
+		}
+	}
+	t.root.color = black
+}
+
+func (t *IntervalTree) delete(z *node) {
+	y := z
+	if z.left != nil && z.right != nil {
+		y = z.right
+		for y.left != nil {
+			y = y.left
+		}
+	}
+	x := y.left
+	if x == nil {
+		x = y.right
+	}
+
+	xparent := y.parent
+	if x != nil {
+		x.parent = xparent
+	}
+	t.replaceChild(y, x)
+
+	c := y.color
+	if y != z {
+		if xparent == z {
+			xparent = y
+		}
+		t.replaceChild(z, y)
+		y.left = z.left
+		y.right = z.right
+		if y.left != nil {
+			y.left.parent = y
+		}
+		if y.right != nil {
+			y.right.parent = y
+		}
+		y.color = z.color
+	}
+
+	// y is at the position of z now, so it is updated on the way from xparent to the root
+	for p := xparent; p != nil; p = p.parent {
+		t.updateMax(p)
+	}
+	if c == black {
+		t.deleteFixup(x, xparent)
+	}
+	t.size--
+}
+
+// replaceChild puts y at the position of x in the tree, the children of x and y are not changed
+func (t *IntervalTree) replaceChild(x, y *node) {
+	if y != nil {
+		y.parent = x.parent
+	}
+	if x.parent == nil {
+		t.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+}
+
+func (t *IntervalTree) deleteFixup(x, parent *node) {
+	for x != t.root && getColor(x) == black {
+		if x != nil {
+			parent = x.parent
+		}
+		if x == parent.left {
+			w := parent.right
+			if w.color == red {
+				w.color = black
+				parent.color = red
+				t.leftRotate(parent)
+				w = parent.right
+			}
+			if getColor(w.left) == black && getColor(w.right) == black {
+				w.color = red
+				x = parent
+			} else {
+				if getColor(w.right) == black {
+					w.left.color = black
+					w.color = red
+					t.rightRotate(w)
+					w = parent.right
+				}
+				w.color = parent.color
+				parent.color = black
+				if w.right != nil {
+					w.right.color = black
+				}
+				t.leftRotate(parent)
+				x = t.root
+			}
+		} else {
+			w := parent.left
+			if w.color == red {
+				w.color = black
+				parent.color = red
+				t.rightRotate(parent)
+				w = parent.left
+			}
+			if getColor(w.left) == black && getColor(w.right) == black {
+				w.color = red
+				x = parent
+			} else {
+				if getColor(w.left) == black {
+					w.right.color = black
+					w.color = red
+					t.leftRotate(w)
+					w = parent.left
+				}
+				w.color = parent.color
+				parent.color = black
+				if w.left != nil {
+					w.left.color = black
+				}
+				t.rightRotate(parent)
+				x = t.root
+			}
+		}
+	}
+	if x != nil {
+		x.color = black
+	}
+}
+
+func (t *IntervalTree) leftRotate(x *node) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	t.replaceChild(x, y)
+	y.left = x
+	x.parent = y
+
+	t.updateMax(x)
+	t.updateMax(y)
+}
+
+func (t *IntervalTree) rightRotate(x *node) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	t.replaceChild(x, y)
+	y.right = x
+	x.parent = y
+
+	t.updateMax(x)
+	t.updateMax(y)
+}
+
+func getColor(n *node) color {
+	if n == nil {
+		return black
+	}
+	return n.color
+}
@@ -0,0 +1,142 @@
+package intervaltree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// verify checks the red-black properties and the max augmentation of the subtree rooted at n and returns its black height
+func (t *IntervalTree) verify(n *node) (int, error) {
+	if n == nil {
+		return 1, nil
+	}
+	if n.color == red && (getColor(n.left) == red || getColor(n.right) == red) {
+		return 0, fmt.Errorf("red node %v has a red child", n.interval)
+	}
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil && child.parent != n {
+			return 0, fmt.Errorf("wrong parent of node %v", child.interval)
+		}
+	}
+	if n.left != nil && t.less(n, n.left) || n.right != nil && t.less(n.right, n) {
+		return 0, fmt.Errorf("node %v is out of order", n.interval)
+	}
+	max := n.max
+	t.updateMax(n)
+	if t.keyCmp(max, n.max) != 0 {
+		return 0, fmt.Errorf("node %v has max %v, expected %v", n.interval, max, n.max)
+	}
+	lh, err := t.verify(n.left)
+	if err != nil {
+		return 0, err
+	}
+	rh, err := t.verify(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if lh != rh {
+		return 0, fmt.Errorf("node %v has different black heights %v and %v", n.interval, lh, rh)
+	}
+	if n.color == black {
+		lh++
+	}
+	return lh, nil
+}
+
+func lows(intervals []Interval) []interface{} {
+	result := make([]interface{}, 0, len(intervals))
+	for _, interval := range intervals {
+		result = append(result, interval.Low)
+	}
+	return result
+}
+
+func TestIntervalTreeOverlap(t *testing.T) {
+	tree := New()
+	tree.Insert(1, 10, "outer")
+	tree.Insert(2, 3, "nested")
+	tree.Insert(4, 6, "nested2")
+	tree.Insert(10, 12, "touching")
+	tree.Insert(20, 30, "disjoint")
+	assert.Equal(t, 5, tree.Size())
+
+	// nested intervals overlap the outer one
+	assert.Equal(t, []Interval{{1, 10, "outer"}, {2, 3, "nested"}}, tree.QueryOverlap(2, 3))
+	// intervals touching at an endpoint overlap
+	assert.Equal(t, []Interval{{1, 10, "outer"}, {10, 12, "touching"}}, tree.QueryOverlap(10, 10))
+	assert.Equal(t, []Interval{{10, 12, "touching"}, {20, 30, "disjoint"}}, tree.QueryOverlap(12, 20))
+	// disjoint intervals don't overlap
+	assert.Equal(t, []Interval{}, tree.QueryOverlap(13, 19))
+	assert.Equal(t, []Interval{}, tree.QueryOverlap(31, 40))
+	assert.Equal(t, []Interval{}, tree.QueryOverlap(-5, 0))
+	// a query covering everything
+	assert.Equal(t, []interface{}{1, 2, 4, 10, 20}, lows(tree.QueryOverlap(0, 100)))
+
+	assert.True(t, tree.Remove(1, 10))
+	assert.False(t, tree.Remove(1, 10))
+	assert.False(t, tree.Remove(2, 4))
+	assert.Equal(t, []interface{}{10}, lows(tree.QueryOverlap(8, 10)))
+	assert.Equal(t, []interface{}{2, 4, 10, 20}, lows(tree.Intervals()))
+	_, err := tree.verify(tree.root)
+	assert.Nil(t, err)
+
+	tree.Clear()
+	assert.True(t, tree.IsEmpty())
+	assert.Equal(t, []Interval{}, tree.QueryOverlap(0, 100))
+}
+
+func TestIntervalTreeDuplicates(t *testing.T) {
+	tree := New(WithGoroutineSafe())
+	tree.Insert(1, 5, "a")
+	tree.Insert(1, 5, "b")
+	tree.Insert(1, 2, "c")
+	assert.Equal(t, 3, len(tree.QueryOverlap(1, 1)))
+	assert.True(t, tree.Remove(1, 5))
+	assert.Equal(t, 2, tree.Size())
+	assert.Equal(t, 1, len(tree.QueryOverlap(3, 4)))
+
+	assert.Panics(t, func() { tree.Insert(3, 2, nil) })
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	tree := New()
+	r := rand.New(rand.NewSource(1))
+	var intervals []Interval
+	for i := 0; i < 3000; i++ {
+		if len(intervals) > 0 && r.Intn(3) == 0 {
+			j := r.Intn(len(intervals))
+			assert.True(t, tree.Remove(intervals[j].Low, intervals[j].High))
+			intervals = append(intervals[:j], intervals[j+1:]...)
+		} else {
+			low := r.Intn(1000)
+			high := low + r.Intn(50)
+			tree.Insert(low, high, nil)
+			intervals = append(intervals, Interval{Low: low, High: high})
+		}
+		if i%100 == 0 {
+			_, err := tree.verify(tree.root)
+			assert.Nil(t, err)
+		}
+	}
+	_, err := tree.verify(tree.root)
+	assert.Nil(t, err)
+	assert.Equal(t, len(intervals), tree.Size())
+
+	for i := 0; i < 200; i++ {
+		low := r.Intn(1100) - 50
+		high := low + r.Intn(30)
+		expected := 0
+		for _, interval := range intervals {
+			if interval.Low.(int) <= high && interval.High.(int) >= low {
+				expected++
+			}
+		}
+		result := tree.QueryOverlap(low, high)
+		assert.Equal(t, expected, len(result))
+		for j := 1; j < len(result); j++ {
+			assert.True(t, result[j-1].Low.(int) <= result[j].Low.(int))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package kdtree
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/utils/sync"
+	"math"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds KdTree's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets KdTree goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+type node struct {
+	point []float64
+	value interface{}
+	// axis is the dimension the node splits its subtree by, the points less than point[axis] are in left
+	axis  int
+	left  *node
+	right *node
+}
+
+// KdTree is a k-d tree which stores points with a fixed number of dimensions and their values,
+// and supports nearest-neighbor and axis-aligned box queries.
+// The tree is not rebalanced, so points inserted in random order give the best performance.
+type KdTree struct {
+	root   *node
+	dims   int
+	size   int
+	locker sync.Locker
+}
+
+// New creates a new KdTree for points with dims dimensions, it panics if dims is not positive
+func New(dims int, opts ...Option) *KdTree {
+	if dims <= 0 {
+		panic("kdtree: dims must be positive")
+	}
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &KdTree{
+		dims:   dims,
+		locker: option.locker,
+	}
+}
+
+// Insert inserts point with value to the KdTree, the same point can be inserted more than once.
+// The point is copied, and it panics if the length of point is not the number of dimensions.
+func (t *KdTree) Insert(point []float64, value interface{}) {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.checkDims(point)
+	n := &node{point: append([]float64(nil), point...), value: value}
+	t.size++
+	if t.root == nil {
+		t.root = n
+		return
+	}
+	cur := t.root
+	for {
+		next := &cur.right
+		if point[cur.axis] < cur.point[cur.axis] {
+			next = &cur.left
+		}
+		if *next == nil {
+			n.axis = (cur.axis + 1) % t.dims
+			*next = n
+			return
+		}
+		cur = *next
+	}
+}
+
+// Nearest returns the value of the point nearest to point and the euclidean distance between them,
+// or nil and +Inf if the KdTree is empty. It panics if the length of point is not the number of dimensions.
+func (t *KdTree) Nearest(point []float64) (value interface{}, dist float64) {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	t.checkDims(point)
+	var best *node
+	bestDist := math.Inf(1) // squared
+	t.nearest(t.root, point, &best, &bestDist)
+	if best == nil {
+		return nil, math.Inf(1)
+	}
+	return best.value, math.Sqrt(bestDist)
+}
+
+func (t *KdTree) nearest(n *node, point []float64, best **node, bestDist *float64) {
+	if n == nil {
+		return
+	}
+	if d := squaredDistance(n.point, point); d < *bestDist {
+		*best, *bestDist = n, d
+	}
+	diff := point[n.axis] - n.point[n.axis]
+	near, far := n.right, n.left
+	if diff < 0 {
+		near, far = n.left, n.right
+	}
+	t.nearest(near, point, best, bestDist)
+	// the points in far are on the other side of the splitting plane, so they can't be nearer than the plane
+	if diff*diff < *bestDist {
+		t.nearest(far, point, best, bestDist)
+	}
+}
+
+// RangeSearch returns the values of the points inside the box [min, max], boundaries included.
+// It panics if the length of min or max is not the number of dimensions.
+func (t *KdTree) RangeSearch(min, max []float64) []interface{} {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	t.checkDims(min)
+	t.checkDims(max)
+	values := make([]interface{}, 0)
+	t.rangeSearch(t.root, min, max, &values)
+	return values
+}
+
+func (t *KdTree) rangeSearch(n *node, min, max []float64, values *[]interface{}) {
+	if n == nil {
+		return
+	}
+	inside := true
+	for i, v := range n.point {
+		if v < min[i] || v > max[i] {
+			inside = false
+			break
+		}
+	}
+	if inside {
+		*values = append(*values, n.value)
+	}
+	if min[n.axis] < n.point[n.axis] {
+		t.rangeSearch(n.left, min, max, values)
+	}
+	if max[n.axis] >= n.point[n.axis] {
+		t.rangeSearch(n.right, min, max, values)
+	}
+}
+
+// Dims returns the number of dimensions of the points
+func (t *KdTree) Dims() int {
+	return t.dims
+}
+
+// Size returns the number of points in the KdTree
+func (t *KdTree) Size() int {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	return t.size
+}
+
+// Empty returns true if the KdTree contains no points
+func (t *KdTree) Empty() bool {
+	return t.Size() == 0
+}
+
+// Clear removes all the points from the KdTree
+func (t *KdTree) Clear() {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.root = nil
+	t.size = 0
+}
+
+func (t *KdTree) checkDims(point []float64) {
+	if len(point) != t.dims {
+		panic(fmt.Sprintf("kdtree: point has %v dimensions, expected %v", len(point), t.dims))
+	}
+}
+
+func squaredDistance(a, b []float64) float64 {
+	var d float64
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
@@ -0,0 +1,97 @@
+package kdtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestKdTree(t *testing.T) {
+	tree := New(2)
+	assert.True(t, tree.Empty())
+	v, dist := tree.Nearest([]float64{0, 0})
+	assert.Nil(t, v)
+	assert.True(t, math.IsInf(dist, 1))
+	assert.Equal(t, 0, len(tree.RangeSearch([]float64{0, 0}, []float64{1, 1})))
+
+	points := [][]float64{{2, 3}, {5, 4}, {9, 6}, {4, 7}, {8, 1}, {7, 2}}
+	for i, p := range points {
+		tree.Insert(p, i)
+	}
+	// the inserted point is copied
+	points[0][0] = 100
+	assert.Equal(t, 6, tree.Size())
+	assert.Equal(t, 2, tree.Dims())
+
+	v, dist = tree.Nearest([]float64{9, 2})
+	assert.Equal(t, 4, v)
+	assert.Equal(t, math.Sqrt(2), dist)
+	v, dist = tree.Nearest([]float64{2, 3})
+	assert.Equal(t, 0, v)
+	assert.Equal(t, float64(0), dist)
+
+	values := tree.RangeSearch([]float64{4, 2}, []float64{8, 7})
+	sort.Slice(values, func(i, j int) bool { return values[i].(int) < values[j].(int) })
+	assert.Equal(t, []interface{}{1, 3, 5}, values)
+
+	assert.Panics(t, func() { tree.Insert([]float64{1}, nil) })
+	assert.Panics(t, func() { tree.Nearest([]float64{1, 2, 3}) })
+	assert.Panics(t, func() { New(0) })
+
+	tree.Clear()
+	assert.True(t, tree.Empty())
+}
+
+func TestKdTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := New(2, WithGoroutineSafe())
+	points := make([][]float64, 2000)
+	for i := range points {
+		points[i] = []float64{r.Float64() * 100, r.Float64() * 100}
+		tree.Insert(points[i], i)
+	}
+
+	for i := 0; i < 500; i++ {
+		q := []float64{r.Float64()*120 - 10, r.Float64()*120 - 10}
+		bestDist := math.Inf(1)
+		for _, p := range points {
+			bestDist = math.Min(bestDist, math.Hypot(p[0]-q[0], p[1]-q[1]))
+		}
+		v, dist := tree.Nearest(q)
+		assert.InDelta(t, bestDist, dist, 1e-9)
+		p := points[v.(int)]
+		assert.InDelta(t, bestDist, math.Hypot(p[0]-q[0], p[1]-q[1]), 1e-9)
+	}
+
+	for i := 0; i < 100; i++ {
+		x, y := r.Float64()*100, r.Float64()*100
+		min := []float64{x, y}
+		max := []float64{x + r.Float64()*30, y + r.Float64()*30}
+		var expected []int
+		for j, p := range points {
+			if p[0] >= min[0] && p[0] <= max[0] && p[1] >= min[1] && p[1] <= max[1] {
+				expected = append(expected, j)
+			}
+		}
+		var actual []int
+		for _, v := range tree.RangeSearch(min, max) {
+			actual = append(actual, v.(int))
+		}
+		sort.Ints(actual)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestKdTreeDuplicates(t *testing.T) {
+	tree := New(3)
+	for i := 0; i < 10; i++ {
+		tree.Insert([]float64{1, 1, 1}, i)
+	}
+	tree.Insert([]float64{1, 2, 1}, 10)
+	assert.Equal(t, 10, len(tree.RangeSearch([]float64{1, 1, 1}, []float64{1, 1, 1})))
+	v, dist := tree.Nearest([]float64{1, 1.9, 1})
+	assert.Equal(t, 10, v)
+	assert.InDelta(t, 0.1, dist, 1e-9)
+}
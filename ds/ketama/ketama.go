@@ -76,7 +76,7 @@ func (k *Ketama) Add(nodes ...string) {
 	defer k.locker.Unlock()
 
 	for _, node := range nodes {
-		hashs := hash.GenHashInts([]byte(salt+node), k.replicas)
+		hashs := hash.GenChainedHashInts([]byte(salt+node), k.replicas)
 		for i := 0; i < k.replicas; i++ {
 			key := hashs[i]
 			if !k.m.Contains(key) {
@@ -92,7 +92,7 @@ func (k *Ketama) Remove(nodes ...string) {
 	defer k.locker.Unlock()
 
 	for _, node := range nodes {
-		hashs := hash.GenHashInts([]byte(salt+node), k.replicas)
+		hashs := hash.GenChainedHashInts([]byte(salt+node), k.replicas)
 		for i := 0; i < k.replicas; i++ {
 			key := hashs[i]
 			iter := k.m.Find(key)
@@ -105,16 +105,15 @@ func (k *Ketama) Remove(nodes ...string) {
 
 // Get returns the node which closest to key in the clockwise direction
 func (k *Ketama) Get(key string) (string, bool) {
-	if k.Empty() {
-		return "", false
-	}
-
 	hashs := hash.GenHashInts([]byte(salt+key), 1)
 	hash := hashs[0]
 
-	k.locker.Lock()
-	defer k.locker.Unlock()
+	k.locker.RLock()
+	defer k.locker.RUnlock()
 
+	if k.m.Size() == 0 {
+		return "", false
+	}
 	iter := k.m.LowerBound(hash)
 	if iter.IsValid() {
 		return iter.Value().(string), true
@@ -25,3 +25,45 @@ func TestKetama(t *testing.T) {
 		t.Logf("%v : %v %v", i, node, ok)
 	}
 }
+
+func TestKetamaStable(t *testing.T) {
+	k := New(WithReplicas(100))
+	_, ok := k.Get("a")
+	if ok {
+		t.Fatalf("expect false on an empty ring")
+	}
+	k.Add("1.1.1.1", "2.2.2.2", "3.3.3.3", "4.4.4.4")
+
+	const n = 10000
+	before := make([]string, n)
+	for i := 0; i < n; i++ {
+		before[i], _ = k.Get(strconv.Itoa(i))
+		if node, _ := k.Get(strconv.Itoa(i)); node != before[i] {
+			t.Fatalf("expect %v, but get %v", before[i], node)
+		}
+	}
+
+	// only the keys moving to the new node are reassigned, about 1/5 of all keys
+	k.Add("5.5.5.5")
+	moved := 0
+	for i := 0; i < n; i++ {
+		node, _ := k.Get(strconv.Itoa(i))
+		if node != before[i] {
+			moved++
+			if node != "5.5.5.5" {
+				t.Fatalf("key %v moves from %v to %v", i, before[i], node)
+			}
+		}
+	}
+	if moved == 0 || moved > n/3 {
+		t.Fatalf("expect a minority of keys to be reassigned, but %v of %v", moved, n)
+	}
+
+	// removing the new node restores the original assignment
+	k.Remove("5.5.5.5")
+	for i := 0; i < n; i++ {
+		if node, _ := k.Get(strconv.Itoa(i)); node != before[i] {
+			t.Fatalf("expect %v, but get %v", before[i], node)
+		}
+	}
+}
@@ -14,15 +14,40 @@ type Node struct {
 	prev  *Node
 	next  *Node
 	Value interface{}
-	list  *List
+	owner *owner
+}
+
+// owner records the list of the nodes pointing to it. Splice links the owners of the two lists like a union-find
+// instead of updating every node, so the list of a node is the list of its root owner.
+// The owner with the lower rank is linked to the other one, so that the chains stay short.
+type owner struct {
+	list   *List
+	parent *owner
+	rank   int
+}
+
+func (o *owner) root() *owner {
+	for o.parent != nil {
+		o = o.parent
+	}
+	return o
+}
+
+// list returns the list n belongs to, or nil if n has been removed
+func (n *Node) list() *List {
+	if n.owner == nil {
+		return nil
+	}
+	return n.owner.root().list
 }
 
 // Next returns the next list node or nil.
 func (n *Node) Next() *Node {
-	if n.list == nil {
+	l := n.list()
+	if l == nil {
 		return nil
 	}
-	if n.next == n.list.head {
+	if n.next == l.head {
 		return nil
 	}
 	return n.next
@@ -30,10 +55,11 @@ func (n *Node) Next() *Node {
 
 // Prev returns the previous list node or nil.
 func (n *Node) Prev() *Node {
-	if n.list == nil {
+	l := n.list()
+	if l == nil {
 		return nil
 	}
-	if n == n.list.head {
+	if n == l.head {
 		return nil
 	}
 	return n.prev
@@ -46,8 +72,9 @@ func (n *Node) Prev() *Node {
 //           node6 -- node5 --  node4
 //
 type List struct {
-	head *Node // point to the front Node
-	len  int   // current list length
+	head  *Node  // point to the front Node
+	len   int    // current list length
+	owner *owner // owner of the nodes, created by the first insert
 }
 
 // New news a list
@@ -107,7 +134,7 @@ func (l *List) PushBack(v interface{}) {
 
 // PushBack inserts a new node n with value v at the back of the list and returns n.
 func (l *List) pushBack(v interface{}) *Node {
-	n := &Node{Value: v, list: l}
+	n := l.newNode(v)
 	if l.len == 0 {
 		n.prev = n
 		n.next = n
@@ -128,26 +155,42 @@ func (l *List) PushFront(v interface{}) {
 // If mark is not a node of l list, the list is not modified.
 // The mark must not be nil.
 func (l *List) InsertAfter(v interface{}, mark *Node) *Node {
-	if mark.list != l {
+	if !l.owns(mark) {
 		return nil
 	}
-	return l.insertAfter(&Node{Value: v, list: l}, mark)
+	return l.insertAfter(l.newNode(v), mark)
 }
 
 // InsertBefore inserts a new node n with value v immediately before mark and returns n.
 // If mark is not a node of l list, the list is not modified.
 // The mark must not be nil.
 func (l *List) InsertBefore(v interface{}, mark *Node) *Node {
-	if mark.list != l {
+	if !l.owns(mark) {
 		return nil
 	}
-	n := l.insertAfter(&Node{Value: v, list: l}, mark.prev)
+	n := l.insertAfter(l.newNode(v), mark.prev)
 	if l.head == mark {
 		l.head = n
 	}
 	return n
 }
 
+func (l *List) newNode(v interface{}) *Node {
+	if l.owner == nil {
+		l.owner = &owner{list: l}
+	}
+	return &Node{Value: v, owner: l.owner}
+}
+
+// owns returns true if n is a node of l list, it also points n directly to its root owner to shorten the later lookups
+func (l *List) owns(n *Node) bool {
+	if n.owner == nil {
+		return false
+	}
+	n.owner = n.owner.root()
+	return n.owner.list == l
+}
+
 func (l *List) insertAfter(n, at *Node) *Node {
 	n.next = at.next
 	n.prev = at
@@ -161,7 +204,7 @@ func (l *List) insertAfter(n, at *Node) *Node {
 // It returns the n value n.Value.
 // The node must not be nil.
 func (l *List) Remove(n *Node) interface{} {
-	if n.list == l {
+	if l.owns(n) {
 		l.remove(n)
 	}
 	return n.Value
@@ -175,7 +218,7 @@ func (l *List) remove(n *Node) *Node {
 	n.next.prev = n.prev
 	n.next = nil // avoid memory leaks
 	n.prev = nil // avoid memory leaks
-	n.list = nil
+	n.owner = nil
 	l.len--
 	if l.len == 0 {
 		l.head = nil
@@ -185,6 +228,11 @@ func (l *List) remove(n *Node) *Node {
 
 // Clear remove all nodes
 func (l *List) Clear() {
+	if l.owner != nil {
+		// detach the removed nodes from l in O(1) time
+		l.owner.list = nil
+		l.owner = nil
+	}
 	l.head = nil
 	l.len = 0
 }
@@ -211,32 +259,42 @@ func (l *List) PopFront() interface{} {
 // If n is not a node of l list, the list is not modified.
 // The n must not be nil.
 func (l *List) MoveToFront(n *Node) {
-	if n.list != l {
+	if !l.owns(n) {
 		return
 	}
-	if l.head != n {
+	if l.head == n {
+		return
+	}
+	if l.head.prev != n {
 		l.moveToAfter(n, l.head.prev)
-		l.head = n
 	}
+	// the list is circular, so the back node becomes the front node by moving the head
+	l.head = n
 }
 
 // MoveToBack moves node  n to the back of l list.
 // If e is not a node of l list, the list is not modified.
 // The node must not be nil.
 func (l *List) MoveToBack(n *Node) {
-	if n.list != l {
+	if !l.owns(n) {
 		return
 	}
-	if l.head.prev != n {
-		l.moveToAfter(n, l.head.prev)
+	if l.head.prev == n {
+		return
 	}
+	if l.head == n {
+		// the list is circular, so the front node becomes the back node by moving the head
+		l.head = n.next
+		return
+	}
+	l.moveToAfter(n, l.head.prev)
 }
 
 // MoveAfter moves node n to its new position after mark.
 // If n or mark is not a node of l list, or n == mark, the list is not modified.
 // The node and mark must not be nil.
 func (l *List) MoveAfter(n, mark *Node) {
-	if n.list != l || n == mark || mark.list != l {
+	if n == mark || !l.owns(n) || !l.owns(mark) {
 		return
 	}
 	l.moveToAfter(n, mark)
@@ -273,6 +331,50 @@ func (l *List) PushFrontList(other *List) {
 	}
 }
 
+// Splice moves all nodes of the other list to l list immediately before mark, or to the back of l list if mark is nil.
+// The nodes are relinked without copying values in O(1) time, the other list becomes empty after splicing.
+// If mark is not a node of l list, or other is l list itself, the lists are not modified.
+func (l *List) Splice(mark *Node, other *List) {
+	if other == l || other.len == 0 || (mark != nil && !l.owns(mark)) {
+		return
+	}
+	if l.owner == nil {
+		l.owner = &owner{list: l}
+	}
+	// the moved nodes keep their owner, which now resolves to l, and other gets a new owner on its next insert
+	root, child := l.owner, other.owner
+	if root.rank < child.rank {
+		root, child = child, root
+	}
+	child.parent = root
+	if root.rank == child.rank {
+		root.rank++
+	}
+	root.list = l
+	l.owner = root
+	other.owner = nil
+	first := other.head
+	last := other.head.prev
+	if l.len == 0 {
+		l.head = first
+	} else {
+		at := l.head.prev
+		if mark != nil {
+			at = mark.prev
+		}
+		last.next = at.next
+		at.next.prev = last
+		at.next = first
+		first.prev = at
+		if mark == l.head {
+			l.head = first
+		}
+	}
+	l.len += other.len
+	other.head = nil
+	other.len = 0
+}
+
 // String returns the list content in string format
 func (l *List) String() string {
 	str := "["
@@ -128,3 +128,145 @@ func TestListIterator(t *testing.T) {
 	assert.Equal(t, 2, iter.Value())
 	assert.True(t, iter.Equal(iter.Clone()))
 }
+
+func TestListSplice(t *testing.T) {
+	newList := func(values ...int) *List {
+		l := New()
+		for _, v := range values {
+			l.PushBack(v)
+		}
+		return l
+	}
+
+	l := newList(1, 2, 3)
+	other := newList(4, 5)
+	l.Splice(nil, other)
+	assert.Equal(t, "[1 2 3 4 5]", l.String())
+	assert.Equal(t, 5, l.Size())
+	assert.True(t, other.Empty())
+	assert.Equal(t, "[]", other.String())
+
+	other = newList(6, 7)
+	l.Splice(l.FrontNode(), other)
+	assert.Equal(t, "[6 7 1 2 3 4 5]", l.String())
+	assert.Equal(t, 7, l.Size())
+	assert.Equal(t, 0, other.Size())
+
+	other = newList(8)
+	l.Splice(l.FrontNode().Next().Next(), other)
+	assert.Equal(t, "[6 7 8 1 2 3 4 5]", l.String())
+	assert.Equal(t, 5, l.BackNode().Value)
+	assert.Equal(t, 8, l.Size())
+
+	l.Splice(nil, New())
+	assert.Equal(t, 8, l.Size())
+	l.Splice(l.FrontNode(), l)
+	assert.Equal(t, "[6 7 8 1 2 3 4 5]", l.String())
+
+	other = newList(9)
+	l.Splice(newList(1).FrontNode(), other)
+	assert.Equal(t, 1, other.Size())
+
+	empty := New()
+	empty.Splice(nil, l)
+	assert.Equal(t, "[6 7 8 1 2 3 4 5]", empty.String())
+	assert.True(t, l.Empty())
+
+	n := empty.FrontNode().Next()
+	assert.Equal(t, 7, empty.Remove(n))
+	assert.Equal(t, "[6 8 1 2 3 4 5]", empty.String())
+
+	values := []interface{}{}
+	for n := empty.BackNode(); n != nil; n = n.Prev() {
+		values = append(values, n.Value)
+	}
+	assert.Equal(t, []interface{}{5, 4, 3, 2, 1, 8, 6}, values)
+}
+
+func TestListSpliceOwnership(t *testing.T) {
+	a, b, c := New(), New(), New()
+	a.PushBack(1)
+	n1 := a.FrontNode()
+	a.PushBack(2)
+	b.PushBack(3)
+	n3 := b.FrontNode()
+	c.PushBack(4)
+
+	b.Splice(nil, a)
+	c.Splice(c.FrontNode(), b)
+	assert.Equal(t, "[3 1 2 4]", c.String())
+	assert.True(t, a.Empty())
+	assert.True(t, b.Empty())
+
+	// the moved nodes belong to c only
+	assert.Nil(t, a.InsertAfter(0, n1))
+	assert.Nil(t, b.InsertAfter(0, n3))
+	assert.Equal(t, 1, a.Remove(n1))
+	assert.Equal(t, 4, c.Size())
+	c.MoveToBack(n1)
+	c.MoveToFront(n3)
+	assert.Equal(t, "[3 2 4 1]", c.String())
+	assert.Equal(t, 1, c.Remove(n1))
+	assert.Equal(t, "[3 2 4]", c.String())
+	assert.Nil(t, n1.Next())
+
+	// the moved-from lists are still usable and don't share nodes with c
+	a.PushBack(5)
+	b.PushFront(6)
+	assert.Equal(t, "[5]", a.String())
+	assert.Equal(t, "[6]", b.String())
+	assert.Nil(t, c.InsertBefore(0, a.FrontNode()))
+	assert.Equal(t, 3, c.Size())
+
+	// the nodes of a cleared list don't belong to it any more
+	c.Clear()
+	assert.Nil(t, n3.Next())
+	assert.Nil(t, c.InsertAfter(0, n3))
+	c.PushBack(7)
+	assert.Equal(t, "[7]", c.String())
+}
+
+func TestListMoveToFrontBack(t *testing.T) {
+	list := New()
+	for i := 1; i <= 3; i++ {
+		list.PushBack(i)
+	}
+	list.MoveToFront(list.BackNode())
+	assert.Equal(t, "[3 1 2]", list.String())
+	list.MoveToFront(list.FrontNode())
+	assert.Equal(t, "[3 1 2]", list.String())
+	list.MoveToFront(list.FrontNode().Next())
+	assert.Equal(t, "[1 3 2]", list.String())
+
+	list.MoveToBack(list.FrontNode())
+	assert.Equal(t, "[3 2 1]", list.String())
+	list.MoveToBack(list.BackNode())
+	assert.Equal(t, "[3 2 1]", list.String())
+	list.MoveToBack(list.FrontNode().Next())
+	assert.Equal(t, "[3 1 2]", list.String())
+	assert.Equal(t, 3, list.Len())
+	assert.Equal(t, 2, list.PopBack())
+	assert.Equal(t, 3, list.PopFront())
+	assert.Equal(t, 1, list.PopBack())
+	assert.True(t, list.Empty())
+}
+
+func BenchmarkListSplice(b *testing.B) {
+	l, other := New(), New()
+	for i := 0; i < 100000; i++ {
+		l.PushBack(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		other.Splice(nil, l)
+		l, other = other, l
+	}
+	b.StopTimer()
+	n := 0
+	for node := l.FrontNode(); node != nil; node = node.Next() {
+		n++
+	}
+	if n != 100000 {
+		b.Fatalf("got %v nodes", n)
+	}
+}
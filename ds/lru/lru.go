@@ -0,0 +1,180 @@
+package lru
+
+import (
+	"github.com/liyue201/gostl/ds/hashmap"
+	"github.com/liyue201/gostl/ds/list/bidlist"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds Cache's options
+type Options struct {
+	locker  sync.Locker
+	onEvict func(key, value interface{})
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets Cache goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithOnEvict sets a callback which is called with the key and value of every entry evicted for lack of capacity
+func WithOnEvict(fn func(key, value interface{})) Option {
+	return func(option *Options) {
+		option.onEvict = fn
+	}
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+}
+
+// Cache is a fixed capacity cache which evicts the least recently used entry when it is full.
+// It uses a List to keep the entries in recency order and a HashMap to find the list node by key,
+// so that all operations take O(1) time.
+type Cache struct {
+	capacity int
+	list     *bidlist.List    // the most recently used entry is at the front
+	nodes    *hashmap.HashMap // key -> *bidlist.Node
+	onEvict  func(key, value interface{})
+	locker   sync.Locker
+}
+
+// New creates a Cache holding at most capacity entries, capacity must be positive
+func New(capacity int, opts ...Option) *Cache {
+	if capacity <= 0 {
+		panic("lru: capacity must be positive")
+	}
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &Cache{
+		capacity: capacity,
+		list:     bidlist.New(),
+		nodes:    hashmap.New(hashmap.WithCapacity(capacity)),
+		onEvict:  option.onEvict,
+		locker:   option.locker,
+	}
+}
+
+// Put inserts or updates the value of key and marks it as the most recently used,
+// the least recently used entry is evicted if the Cache is full.
+func (c *Cache) Put(key, value interface{}) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	if n, ok := c.nodes.Get(key); ok {
+		node := n.(*bidlist.Node)
+		node.Value.(*entry).value = value
+		c.list.MoveToFront(node)
+		return
+	}
+	if c.list.Size() >= c.capacity {
+		c.evict()
+	}
+	c.list.PushFront(&entry{key: key, value: value})
+	c.nodes.Insert(key, c.list.FrontNode())
+}
+
+func (c *Cache) evict() {
+	e := c.list.PopBack().(*entry)
+	c.nodes.Erase(e.key)
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// Get returns the value of key and true if found, and marks it as the most recently used. Otherwise returns nil and false.
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	n, ok := c.nodes.Get(key)
+	if !ok {
+		return nil, false
+	}
+	node := n.(*bidlist.Node)
+	c.list.MoveToFront(node)
+	return node.Value.(*entry).value, true
+}
+
+// Peek returns the value of key and true if found without changing its recency. Otherwise returns nil and false.
+func (c *Cache) Peek(key interface{}) (interface{}, bool) {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	n, ok := c.nodes.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return n.(*bidlist.Node).Value.(*entry).value, true
+}
+
+// Contains returns true if key in the Cache without changing its recency, otherwise returns false
+func (c *Cache) Contains(key interface{}) bool {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	return c.nodes.Contains(key)
+}
+
+// Remove removes key from the Cache and returns true if it exists, the eviction callback is not called
+func (c *Cache) Remove(key interface{}) bool {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	n, ok := c.nodes.Get(key)
+	if !ok {
+		return false
+	}
+	c.list.Remove(n.(*bidlist.Node))
+	c.nodes.Erase(key)
+	return true
+}
+
+// Keys returns all keys in the Cache from the most recently used to the least recently used
+func (c *Cache) Keys() []interface{} {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	keys := make([]interface{}, 0, c.list.Size())
+	for node := c.list.FrontNode(); node != nil; node = node.Next() {
+		keys = append(keys, node.Value.(*entry).key)
+	}
+	return keys
+}
+
+// Size returns the number of entries in the Cache
+func (c *Cache) Size() int {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	return c.list.Size()
+}
+
+// Capacity returns the maximum number of entries the Cache can hold
+func (c *Cache) Capacity() int {
+	return c.capacity
+}
+
+// Clear removes all entries in the Cache, the eviction callback is not called
+func (c *Cache) Clear() {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	c.list.Clear()
+	c.nodes.Clear()
+}
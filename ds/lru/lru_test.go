@@ -0,0 +1,73 @@
+package lru
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	var evicted []interface{}
+	c := New(3, WithOnEvict(func(key, value interface{}) {
+		evicted = append(evicted, key)
+	}))
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+	assert.Equal(t, []interface{}{3, 2, 1}, c.Keys())
+
+	// Get promotes 1, so 2 becomes the least recently used
+	value, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+	c.Put(4, "d")
+	assert.Equal(t, []interface{}{2}, evicted)
+	assert.False(t, c.Contains(2))
+
+	// updating an existing key promotes it without evicting
+	c.Put(3, "cc")
+	assert.Equal(t, []interface{}{3, 4, 1}, c.Keys())
+	assert.Equal(t, 1, len(evicted))
+
+	// Peek doesn't promote
+	value, ok = c.Peek(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+	c.Put(5, "e")
+	c.Put(6, "f")
+	assert.Equal(t, []interface{}{2, 1, 4}, evicted)
+	assert.Equal(t, []interface{}{6, 5, 3}, c.Keys())
+	value, _ = c.Get(3)
+	assert.Equal(t, "cc", value)
+
+	assert.True(t, c.Remove(5))
+	assert.False(t, c.Remove(5))
+	assert.Equal(t, 2, c.Size())
+	assert.Equal(t, 3, c.Capacity())
+	c.Clear()
+	assert.Equal(t, 0, c.Size())
+	assert.Equal(t, 3, len(evicted))
+
+	assert.Panics(t, func() { New(0) })
+}
+
+func TestCacheGoroutineSafe(t *testing.T) {
+	c := New(100, WithGoroutineSafe())
+	wg := sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Put(g*1000+i, i)
+				c.Get(g*1000 + i/2)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 100, c.Size())
+	assert.Equal(t, 100, len(c.Keys()))
+}
@@ -0,0 +1,106 @@
+package treemap
+
+import (
+	"github.com/liyue201/gostl/ds/rbtree"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// FrozenMap is an immutable copy of a Map returned by Map.Freeze. Its mutating methods panic and it holds no lock,
+// so it can be read from any number of goroutines while the source Map keeps changing.
+type FrozenMap struct {
+	tree   *rbtree.RbTree
+	keyCmp comparator.Comparator
+}
+
+// Freeze returns an immutable copy of the Map as it is now, later changes to the Map are not visible in it.
+// Note that it is not a cheap snapshot: the tree is copied without structural sharing, so every call takes O(n) time
+// and memory while the Map is read-locked, and it suits Maps that are frozen much less often than they are read.
+// After that reading the copy never blocks writers of the Map.
+func (m *Map) Freeze() *FrozenMap {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return &FrozenMap{tree: m.tree.Clone(), keyCmp: m.keyCmp}
+}
+
+const errFrozen = "treemap: FrozenMap is immutable"
+
+// Insert panics because a FrozenMap is immutable
+func (f *FrozenMap) Insert(key, value interface{}) {
+	panic(errFrozen)
+}
+
+// Erase panics because a FrozenMap is immutable
+func (f *FrozenMap) Erase(key interface{}) {
+	panic(errFrozen)
+}
+
+// Clear panics because a FrozenMap is immutable
+func (f *FrozenMap) Clear() {
+	panic(errFrozen)
+}
+
+// Get returns the value by key if found, or nil if not found
+func (f *FrozenMap) Get(key interface{}) interface{} {
+	node := f.tree.FindNode(key)
+	if node != nil {
+		return node.Value()
+	}
+	return nil
+}
+
+// Contains returns true if key in the FrozenMap. otherwise returns false.
+func (f *FrozenMap) Contains(key interface{}) bool {
+	return f.tree.FindNode(key) != nil
+}
+
+// Size returns the number of elements in the FrozenMap
+func (f *FrozenMap) Size() int {
+	return f.tree.Size()
+}
+
+// IsEmpty returns true if the FrozenMap contains no elements
+func (f *FrozenMap) IsEmpty() bool {
+	return f.tree.Size() == 0
+}
+
+// Keys returns all keys in the FrozenMap in ascending order
+func (f *FrozenMap) Keys() []interface{} {
+	keys := make([]interface{}, 0, f.tree.Size())
+	for node := f.tree.First(); node != nil; node = node.Next() {
+		keys = append(keys, node.Key())
+	}
+	return keys
+}
+
+// Values returns all values in the FrozenMap in ascending order of their keys
+func (f *FrozenMap) Values() []interface{} {
+	values := make([]interface{}, 0, f.tree.Size())
+	for node := f.tree.First(); node != nil; node = node.Next() {
+		values = append(values, node.Value())
+	}
+	return values
+}
+
+// Traversal traversals elements in the FrozenMap, it will not stop until to the end or visitor returns false
+func (f *FrozenMap) Traversal(visitor visitor.KvVisitor) {
+	f.tree.Traversal(visitor)
+}
+
+// Range calls fn for every element with key in range [lo, hi) in ascending order of keys, and stops if fn returns false.
+// A nil lo or hi means the range is unbounded on that side, Unbounded can be used instead of nil.
+func (f *FrozenMap) Range(lo, hi interface{}, fn func(key, value interface{}) bool) {
+	node := f.tree.First()
+	if !isOpen(lo) {
+		node = f.tree.FindLowerBoundNode(lo)
+	}
+	for ; node != nil; node = node.Next() {
+		if !isOpen(hi) && f.keyCmp(node.Key(), hi) >= 0 {
+			break
+		}
+		if !fn(node.Key(), node.Value()) {
+			break
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package treemap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestFrozenMap(t *testing.T) {
+	m := New()
+	assert.True(t, m.Freeze().IsEmpty())
+
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	f := m.Freeze()
+	assert.Panics(t, func() { f.Insert(1, 1) })
+	assert.Panics(t, func() { f.Erase(1) })
+	assert.Panics(t, func() { f.Clear() })
+	m.Erase(3)
+	m.Insert(3, "x")
+	m.Insert(100, 100)
+
+	assert.Equal(t, 10, f.Size())
+	assert.Equal(t, 30, f.Get(3))
+	assert.Nil(t, f.Get(100))
+	assert.True(t, f.Contains(9))
+	assert.False(t, f.Contains(100))
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, f.Keys())
+	assert.Equal(t, 0, f.Values()[0])
+
+	var keys []interface{}
+	f.Range(3, 6, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{3, 4, 5}, keys)
+
+	keys = nil
+	f.Range(Unbounded, 2, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{0, 1}, keys)
+
+	keys = nil
+	f.Range(8, Unbounded, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{8, 9}, keys)
+
+	keys = nil
+	f.Range(nil, 2, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{0, 1}, keys)
+
+	keys = nil
+	f.Range(8, nil, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{8, 9}, keys)
+
+	n := 0
+	f.Traversal(func(key, value interface{}) bool {
+		n++
+		return n < 5
+	})
+	assert.Equal(t, 5, n)
+}
+
+// TestFrozenMapConcurrent reads a frozen copy without any lock while the source Map is being written,
+// the copy shares no nodes with the source so the readers must always see the original content.
+func TestFrozenMapConcurrent(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, i)
+	}
+	f := m.Freeze()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			m.Erase(i)
+			m.Insert(i+1000, i)
+		}
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				assert.Equal(t, i, f.Get(i))
+			}
+			assert.Equal(t, 1000, f.Size())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, f.Size())
+	assert.False(t, m.Contains(0))
+}
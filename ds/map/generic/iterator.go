@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package generic
+
+import (
+	"github.com/liyue201/gostl/ds/rbtree"
+)
+
+// Iterator is a typed iterator for TreeMap, it moves over the nodes by an rbtree.RbTreeIterator
+type Iterator[K any, V any] struct {
+	cur rbtree.RbTreeIterator
+}
+
+func newIterator[K any, V any](node *rbtree.Node) *Iterator[K, V] {
+	return &Iterator[K, V]{cur: *rbtree.NewIterator(node)}
+}
+
+// IsValid returns whether iter is valid
+func (iter *Iterator[K, V]) IsValid() bool {
+	return iter.cur.IsValid()
+}
+
+// Next moves iter to the next node and returns iter
+func (iter *Iterator[K, V]) Next() *Iterator[K, V] {
+	iter.cur.Next()
+	return iter
+}
+
+// Prev moves iter to the previous node and returns iter
+func (iter *Iterator[K, V]) Prev() *Iterator[K, V] {
+	iter.cur.Prev()
+	return iter
+}
+
+// Key returns the key of iter
+func (iter *Iterator[K, V]) Key() K {
+	return as[K](iter.cur.Key())
+}
+
+// Value returns the value of iter
+func (iter *Iterator[K, V]) Value() V {
+	return as[V](iter.cur.Value())
+}
+
+// SetValue sets the value of iter
+func (iter *Iterator[K, V]) SetValue(value V) {
+	iter.cur.SetValue(value)
+}
+
+// Clone clones iter to a new Iterator
+func (iter *Iterator[K, V]) Clone() *Iterator[K, V] {
+	return newIterator[K, V](iter.cur.Node())
+}
+
+// Equal returns whether iter is equal to other
+func (iter *Iterator[K, V]) Equal(other *Iterator[K, V]) bool {
+	return other != nil && iter.cur.Equal(&other.cur)
+}
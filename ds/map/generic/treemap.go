@@ -0,0 +1,181 @@
+//go:build go1.18
+// +build go1.18
+
+package generic
+
+import (
+	"github.com/liyue201/gostl/ds/rbtree"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+var defaultLocker sync.FakeLocker
+
+// Comparator is a typed comparator, it should return a number:
+//    -1 , if a < b
+//    0  , if a == b
+//    1  , if a > b
+type Comparator[K any] func(a, b K) int
+
+// Options holds TreeMap's options
+type Options[K any] struct {
+	keyCmp Comparator[K]
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option[K any] func(option *Options[K])
+
+// WithKeyComparator sets Key comparator option
+func WithKeyComparator[K any](cmp Comparator[K]) Option[K] {
+	return func(option *Options[K]) {
+		option.keyCmp = cmp
+	}
+}
+
+// WithGoroutineSafe set TreeMap goroutine-safety,
+// Note that iterators are not goroutine safe, so don't use iterators in multi goroutines
+func WithGoroutineSafe[K any]() Option[K] {
+	return func(option *Options[K]) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// TreeMap is a typed version of treemap.Map, it uses RbTress for internal data structure,
+// and every key must be unique.
+type TreeMap[K any, V any] struct {
+	tree   *rbtree.RbTree
+	locker sync.Locker
+}
+
+// New news a TreeMap, if no comparator is set, comparator.BuiltinTypeComparator is used,
+// so make sure K is a builtin type in that case.
+func New[K any, V any](opts ...Option[K]) *TreeMap[K, V] {
+	option := Options[K]{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	keyCmp := comparator.BuiltinTypeComparator
+	if option.keyCmp != nil {
+		cmp := option.keyCmp
+		keyCmp = func(a, b interface{}) int {
+			return cmp(as[K](a), as[K](b))
+		}
+	}
+	return &TreeMap[K, V]{
+		tree:   rbtree.New(rbtree.WithKeyComparator(keyCmp)),
+		locker: option.locker,
+	}
+}
+
+// Insert inserts key-value to the TreeMap
+func (m *TreeMap[K, V]) Insert(key K, value V) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		node.SetValue(value)
+		return
+	}
+	m.tree.Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or the zero value of V and false if not found
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		return as[V](node.Value()), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Erase erases node by key in the TreeMap
+func (m *TreeMap[K, V]) Erase(key K) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		m.tree.Delete(node)
+	}
+}
+
+// Contains returns true if key in the TreeMap. otherwise returns false.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.tree.FindNode(key) != nil
+}
+
+// Find returns the iterator related to key in the TreeMap, or an invalid iterator if not exist.
+func (m *TreeMap[K, V]) Find(key K) *Iterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newIterator[K, V](m.tree.FindNode(key))
+}
+
+// LowerBound returns the first iterator that equal or greater than key in the TreeMap
+func (m *TreeMap[K, V]) LowerBound(key K) *Iterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newIterator[K, V](m.tree.FindLowerBoundNode(key))
+}
+
+// Begin returns the iterator with the minimum key in the TreeMap, return an invalid iterator if empty.
+func (m *TreeMap[K, V]) Begin() *Iterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newIterator[K, V](m.tree.First())
+}
+
+// Last returns the iterator with the maximum key in the TreeMap, return an invalid iterator if empty.
+func (m *TreeMap[K, V]) Last() *Iterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newIterator[K, V](m.tree.Last())
+}
+
+// Clear clears the TreeMap
+func (m *TreeMap[K, V]) Clear() {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.tree.Clear()
+}
+
+// Size returns the size of TreeMap
+func (m *TreeMap[K, V]) Size() int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.tree.Size()
+}
+
+// Traversal traversals elements in TreeMap, it will not stop until to the end or visitor returns false
+func (m *TreeMap[K, V]) Traversal(fn func(key K, value V) bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	m.tree.Traversal(func(key, value interface{}) bool {
+		return fn(as[K](key), as[V](value))
+	})
+}
+
+// as converts v stored in the tree back to T, a nil v of an interface type T becomes the zero value of T
+func as[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
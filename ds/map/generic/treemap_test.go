@@ -0,0 +1,104 @@
+//go:build go1.18
+// +build go1.18
+
+package generic
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	m := New[int, string]()
+	assert.Equal(t, 0, m.Size())
+	v, ok := m.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+
+	for i := 9; i >= 0; i-- {
+		m.Insert(i, strings.Repeat("a", i))
+	}
+	assert.Equal(t, 10, m.Size())
+	v, ok = m.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "aaa", v)
+
+	m.Erase(3)
+	_, ok = m.Get(3)
+	assert.False(t, ok)
+	assert.False(t, m.Contains(3))
+	assert.True(t, m.Contains(4))
+
+	i := 0
+	for iter := m.Begin(); iter.IsValid(); iter.Next() {
+		if i == 3 {
+			i++
+		}
+		assert.Equal(t, i, iter.Key())
+		assert.Equal(t, strings.Repeat("a", i), iter.Value())
+		i++
+	}
+	assert.Equal(t, 10, i)
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.False(t, m.Begin().IsValid())
+}
+
+func TestTreeMapComparator(t *testing.T) {
+	m := New[string, int](WithKeyComparator(func(a, b string) int {
+		return strings.Compare(b, a)
+	}), WithGoroutineSafe[string]())
+	m.Insert("a", 1)
+	m.Insert("c", 3)
+	m.Insert("b", 2)
+	m.Insert("b", 20)
+
+	var keys []string
+	var values []int
+	m.Traversal(func(key string, value int) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []string{"c", "b", "a"}, keys)
+	assert.Equal(t, []int{3, 20, 1}, values)
+
+	iter := m.LowerBound("bb")
+	assert.Equal(t, "b", iter.Key())
+	iter.SetValue(200)
+	v, _ := m.Get("b")
+	assert.Equal(t, 200, v)
+
+	clone := iter.Clone()
+	iter.Next()
+	assert.Equal(t, "b", clone.Key())
+	assert.True(t, iter.Equal(m.Find("a")))
+	assert.True(t, m.Last().Equal(iter))
+	assert.Equal(t, "b", iter.Prev().Key())
+}
+
+func TestTreeMapNilInterfaceValue(t *testing.T) {
+	m := New[string, error]()
+	m.Insert("ok", nil)
+	m.Insert("failed", errors.New("failed"))
+
+	err, ok := m.Get("ok")
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	err, ok = m.Get("failed")
+	assert.True(t, ok)
+	assert.EqualError(t, err, "failed")
+
+	iter := m.Find("ok")
+	assert.Nil(t, iter.Value())
+
+	count := 0
+	m.Traversal(func(key string, value error) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,50 @@
+package treemap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+// ErrInvalidGobData is returned by GobDecode when the numbers of the decoded keys and values are not equal
+var ErrInvalidGobData = errors.New("invalid gob data")
+
+type gobEntries struct {
+	Keys   []interface{}
+	Values []interface{}
+}
+
+// GobEncode implements gob.GobEncoder, the elements are encoded in ascending order of keys.
+// The concrete types of keys and values other than the builtin types must be registered by gob.Register.
+func (m *Map) GobEncode() ([]byte, error) {
+	keys, values := m.Entries()
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(gobEntries{Keys: keys, Values: values}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, it accepts the data generated by GobEncode.
+// The existing elements of the Map will be cleared.
+// Note that the comparator can't be encoded, so the Map must be created by New with the right
+// WithKeyComparator option before decoding into it.
+// A zero-value Map, e.g. allocated by encoding/gob for a *Map field, is initialized with the default options.
+func (m *Map) GobDecode(data []byte) error {
+	m.initZero()
+	var entries gobEntries
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return err
+	}
+	if len(entries.Keys) != len(entries.Values) {
+		return ErrInvalidGobData
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.tree.Clear()
+	for i, key := range entries.Keys {
+		m.insert(key, entries.Values[i])
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package treemap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type gobPerson struct {
+	Name string
+	Age  int
+}
+
+func TestMapGob(t *testing.T) {
+	gob.Register(gobPerson{})
+
+	m := New(WithKeyComparator(comparator.Reverse(comparator.StringComparator)))
+	m.Insert("alice", gobPerson{Name: "Alice", Age: 20})
+	m.Insert("bob", gobPerson{Name: "Bob", Age: 30})
+	m.Insert("carol", gobPerson{Name: "Carol", Age: 40})
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(m))
+
+	other := New(WithKeyComparator(comparator.Reverse(comparator.StringComparator)), WithGoroutineSafe())
+	other.Insert("dave", gobPerson{})
+	assert.Nil(t, gob.NewDecoder(buf).Decode(other))
+	assert.Equal(t, []interface{}{"carol", "bob", "alice"}, other.Keys())
+	assert.Equal(t, gobPerson{Name: "Bob", Age: 30}, other.Get("bob"))
+	assert.True(t, m.Equal(other, nil))
+
+	// the order follows the comparator of the decoding Map
+	data, err := m.GobEncode()
+	assert.Nil(t, err)
+	asc := New()
+	assert.Nil(t, asc.GobDecode(data))
+	assert.Equal(t, []interface{}{"alice", "bob", "carol"}, asc.Keys())
+
+	assert.NotNil(t, asc.GobDecode([]byte("invalid")))
+}
+
+func TestMapGobField(t *testing.T) {
+	type wrapper struct {
+		M *Map
+	}
+	src := wrapper{M: New()}
+	src.M.Insert("b", 2)
+	src.M.Insert("a", 1)
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(src))
+
+	var dst wrapper
+	assert.Nil(t, gob.NewDecoder(buf).Decode(&dst))
+	assert.Equal(t, []interface{}{"a", "b"}, dst.M.Keys())
+	assert.True(t, src.M.Equal(dst.M, nil))
+	dst.M.Insert("c", 3)
+	assert.Equal(t, 3, dst.M.Size())
+}
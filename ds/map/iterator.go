@@ -1,27 +1,37 @@
 package treemap
 
 import (
+	"errors"
 	"github.com/liyue201/gostl/ds/rbtree"
 	"github.com/liyue201/gostl/utils/iterator"
 )
 
-// MapIterator is an iterator for Map
+// ErrInvalidIterator is returned when setting the value of an invalid iterator
+var ErrInvalidIterator = errors.New("invalid iterator")
+
+// MapIterator is an iterator for Map, it moves over the nodes by an rbtree.RbTreeIterator
 type MapIterator struct {
-	node *rbtree.Node
+	cur rbtree.RbTreeIterator
+	// unlock releases the read lock held by the iterator, see WithThreadSafeIterators
+	unlock func()
+}
+
+func newMapIterator(node *rbtree.Node) *MapIterator {
+	return &MapIterator{cur: *rbtree.NewIterator(node)}
 }
 
 // IsValid returns whether iter is valid
 func (iter *MapIterator) IsValid() bool {
-	if iter.node != nil {
-		return true
-	}
-	return false
+	return iter.cur.IsValid()
 }
 
 // Next returns the next iterator
 func (iter *MapIterator) Next() iterator.ConstIterator {
 	if iter.IsValid() {
-		iter.node = iter.node.Next()
+		iter.cur.Next()
+		if !iter.cur.IsValid() {
+			iter.Close()
+		}
 	}
 	return iter
 }
@@ -29,40 +39,136 @@ func (iter *MapIterator) Next() iterator.ConstIterator {
 // Prev returns the previous iterator
 func (iter *MapIterator) Prev() iterator.ConstBidIterator {
 	if iter.IsValid() {
-		iter.node = iter.node.Prev()
+		iter.cur.Prev()
+		if !iter.cur.IsValid() {
+			iter.Close()
+		}
 	}
 	return iter
 }
 
-// Key returns the key of iter
+// Close releases the read lock of the Map held by iter if the Map is created with WithThreadSafeIterators,
+// otherwise it does nothing. iter can still be used after closing but it is not protected by the lock any more.
+// It is safe to call Close more than once.
+func (iter *MapIterator) Close() {
+	if iter.unlock != nil {
+		iter.unlock()
+		iter.unlock = nil
+	}
+}
+
+// Key returns the key of iter, or nil if iter is invalid
 func (iter *MapIterator) Key() interface{} {
-	return iter.node.Key()
+	if !iter.IsValid() {
+		return nil
+	}
+	return iter.cur.Key()
 }
 
-// Value returns the value of iter
+// Value returns the value of iter, or nil if iter is invalid
 func (iter *MapIterator) Value() interface{} {
-	return iter.node.Value()
+	if !iter.IsValid() {
+		return nil
+	}
+	return iter.cur.Value()
 }
 
-// SetValue sets the value of iter
+// SetValue sets the value of iter in place without searching the Map again, it takes O(1) time.
+// It returns ErrInvalidIterator if iter is invalid
 func (iter *MapIterator) SetValue(val interface{}) error {
-	iter.node.SetValue(val)
-	return nil
+	if !iter.IsValid() {
+		return ErrInvalidIterator
+	}
+	return iter.cur.SetValue(val)
 }
 
-// Clone clones iter to a new MapIterator
+// Clone clones iter to a new MapIterator, the clone doesn't hold the read lock held by iter.
+// It returns iterator.ConstIterator so that MapIterator implements the iterator interfaces, use Copy to get a *MapIterator.
 func (iter *MapIterator) Clone() iterator.ConstIterator {
-	return &MapIterator{iter.node}
+	return iter.Copy()
 }
 
-// Equal returns whether iter is equal to other
+// Copy returns a new MapIterator at the same node as iter which moves independently of iter,
+// the copy doesn't hold the read lock held by iter
+func (iter *MapIterator) Copy() *MapIterator {
+	return newMapIterator(iter.cur.Node())
+}
+
+// Equal returns whether iter is equal to other.
+// It takes iterator.ConstIterator so that MapIterator implements the iterator interfaces, use SamePosition to compare with a *MapIterator.
 func (iter *MapIterator) Equal(other iterator.ConstIterator) bool {
 	otherIter, ok := other.(*MapIterator)
 	if !ok {
 		return false
 	}
-	if otherIter.node == iter.node {
-		return true
+	return iter.SamePosition(otherIter)
+}
+
+// SamePosition returns true if iter and other are at the same node, or both are invalid
+func (iter *MapIterator) SamePosition(other *MapIterator) bool {
+	return other != nil && iter.cur.Equal(&other.cur)
+}
+
+// MapReverseIterator is a reverse iterator for Map, it moves toward the smaller keys when calling Next
+type MapReverseIterator struct {
+	cur rbtree.RbTreeIterator
+}
+
+func newMapReverseIterator(node *rbtree.Node) *MapReverseIterator {
+	return &MapReverseIterator{cur: *rbtree.NewIterator(node)}
+}
+
+// IsValid returns whether iter is valid
+func (iter *MapReverseIterator) IsValid() bool {
+	return iter.cur.IsValid()
+}
+
+// Next moves iter to the node with the next smaller key and returns iter
+func (iter *MapReverseIterator) Next() iterator.ConstIterator {
+	iter.cur.Prev()
+	return iter
+}
+
+// Prev moves iter to the node with the next greater key and returns iter
+func (iter *MapReverseIterator) Prev() iterator.ConstBidIterator {
+	iter.cur.Next()
+	return iter
+}
+
+// Key returns the key of iter, or nil if iter is invalid
+func (iter *MapReverseIterator) Key() interface{} {
+	if !iter.IsValid() {
+		return nil
+	}
+	return iter.cur.Key()
+}
+
+// Value returns the value of iter, or nil if iter is invalid
+func (iter *MapReverseIterator) Value() interface{} {
+	if !iter.IsValid() {
+		return nil
+	}
+	return iter.cur.Value()
+}
+
+// SetValue sets the value of iter in place, it returns ErrInvalidIterator if iter is invalid
+func (iter *MapReverseIterator) SetValue(val interface{}) error {
+	if !iter.IsValid() {
+		return ErrInvalidIterator
+	}
+	return iter.cur.SetValue(val)
+}
+
+// Clone clones iter to a new MapReverseIterator
+func (iter *MapReverseIterator) Clone() iterator.ConstIterator {
+	return newMapReverseIterator(iter.cur.Node())
+}
+
+// Equal returns whether iter is equal to other
+func (iter *MapReverseIterator) Equal(other iterator.ConstIterator) bool {
+	otherIter, ok := other.(*MapReverseIterator)
+	if !ok {
+		return false
 	}
-	return false
+	return iter.cur.Equal(&otherIter.cur)
 }
@@ -0,0 +1,93 @@
+package treemap
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type jsonEntry struct {
+	Key   interface{} `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// If all keys are strings, the Map is encoded as a JSON object with keys in the order of the Map,
+// otherwise it is encoded as an array of {"key":...,"value":...} objects.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	allString := true
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if _, ok := node.Key().(string); !ok {
+			allString = false
+			break
+		}
+	}
+	if !allString {
+		entries := make([]jsonEntry, 0, m.tree.Size())
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			entries = append(entries, jsonEntry{Key: node.Key(), Value: node.Value()})
+		}
+		return json.Marshal(entries)
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte('{')
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if node != m.tree.First() {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(node.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(node.Value())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it accepts the formats generated by MarshalJSON.
+// The existing elements of the Map will be cleared.
+// Note that the comparator can't be encoded, so the Map must be created by New with the right
+// WithKeyComparator option before unmarshaling, the default comparator is BuiltinTypeComparator.
+// A zero-value Map, e.g. allocated by encoding/json for a *Map field, is initialized with the default options.
+// Keys and values are decoded as the default types of encoding/json, e.g. numbers are decoded as float64.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	m.initZero()
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		m.locker.Lock()
+		defer m.locker.Unlock()
+
+		m.tree.Clear()
+		for key, value := range obj {
+			m.insert(key, value)
+		}
+		return nil
+	}
+
+	var entries []jsonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.tree.Clear()
+	for _, entry := range entries {
+		m.insert(entry.Key, entry.Value)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package treemap
+
+// UnsafeMap gives access to a Map without locking, it is only valid inside the callback of Map.LockedDo,
+// where the lock of the Map is already held. It must not be kept or used after the callback returns.
+type UnsafeMap struct {
+	m *Map
+}
+
+// LockedDo calls fn with an UnsafeMap of m under the write lock of m, so that a sequence of operations done by fn is atomic.
+// fn must not call the methods of m, which would deadlock if m is goroutine-safe, it should use u instead.
+func (m *Map) LockedDo(fn func(u *UnsafeMap)) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	fn(&UnsafeMap{m: m})
+}
+
+// Insert inserts key-value to the map, the value will be replaced if key exists
+func (u *UnsafeMap) Insert(key, value interface{}) {
+	u.m.insert(key, value)
+}
+
+// Get returns the value by key and true if found, or nil and false if not found
+func (u *UnsafeMap) Get(key interface{}) (interface{}, bool) {
+	node := u.m.tree.FindNode(key)
+	if node == nil {
+		return nil, false
+	}
+	return node.Value(), true
+}
+
+// Contains returns true if key in the map, otherwise returns false
+func (u *UnsafeMap) Contains(key interface{}) bool {
+	return u.m.tree.FindNode(key) != nil
+}
+
+// Erase erases key from the map and returns true if it was in the map
+func (u *UnsafeMap) Erase(key interface{}) bool {
+	node := u.m.tree.FindNode(key)
+	if node == nil {
+		return false
+	}
+	u.m.tree.Delete(node)
+	return true
+}
+
+// Size returns the size of the map
+func (u *UnsafeMap) Size() int {
+	return u.m.tree.Size()
+}
+
+// ForEachIf calls fn for each key-value in ascending order of keys until fn returns false, fn must not modify the map
+func (u *UnsafeMap) ForEachIf(fn func(key, value interface{}) bool) {
+	for node := u.m.tree.First(); node != nil; node = node.Next() {
+		if !fn(node.Key(), node.Value()) {
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package treemap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestLockedDo(t *testing.T) {
+	m := New()
+	m.Insert("a", 1)
+	m.Insert("b", 2)
+	m.LockedDo(func(u *UnsafeMap) {
+		a, _ := u.Get("a")
+		b, _ := u.Get("b")
+		u.Insert("c", a.(int)+b.(int))
+		assert.True(t, u.Erase("a"))
+		assert.False(t, u.Erase("x"))
+		assert.False(t, u.Contains("a"))
+		_, ok := u.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 2, u.Size())
+
+		var keys []interface{}
+		u.ForEachIf(func(key, value interface{}) bool {
+			keys = append(keys, key)
+			return true
+		})
+		assert.Equal(t, []interface{}{"b", "c"}, keys)
+	})
+	assert.Equal(t, 3, m.Get("c"))
+	assert.Equal(t, []interface{}{"b", "c"}, m.Keys())
+}
+
+func TestLockedDoAtomicity(t *testing.T) {
+	// a transfer between two accounts keeps the total unchanged, while other goroutines write a third key
+	m := New(WithGoroutineSafe())
+	m.Insert("x", 1000)
+	m.Insert("y", 1000)
+	m.Insert("z", 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				from, to := "x", "y"
+				if (i+j)%2 == 0 {
+					from, to = to, from
+				}
+				m.LockedDo(func(u *UnsafeMap) {
+					a, _ := u.Get(from)
+					b, _ := u.Get(to)
+					u.Insert(from, a.(int)-1)
+					u.Insert(to, b.(int)+1)
+					total, _ := u.Get("total")
+					if total != nil {
+						u.Insert("total", total.(int)+1)
+					} else {
+						u.Insert("total", 1)
+					}
+				})
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Update("z", func(old interface{}, exists bool) interface{} {
+					return old.(int) + 1
+				})
+				var sum int
+				m.LockedDo(func(u *UnsafeMap) {
+					x, _ := u.Get("x")
+					y, _ := u.Get("y")
+					sum = x.(int) + y.(int)
+				})
+				assert.Equal(t, 2000, sum)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2000, m.Get("x").(int)+m.Get("y").(int))
+	assert.Equal(t, 2000, m.Get("total"))
+	assert.Equal(t, 2000, m.Get("z"))
+}
@@ -1,12 +1,16 @@
 package treemap
 
 import (
+	"fmt"
 	"github.com/liyue201/gostl/ds/rbtree"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/iterator"
 	"github.com/liyue201/gostl/utils/sync"
 	"github.com/liyue201/gostl/utils/visitor"
+	"math/rand"
+	"reflect"
 	gosync "sync"
+	"unsafe"
 )
 
 var (
@@ -16,8 +20,17 @@ var (
 
 // Options holds Map's options
 type Options struct {
-	keyCmp comparator.Comparator
-	locker sync.Locker
+	keyCmp              comparator.Comparator
+	goroutineSafe       bool
+	threadSafeIterators bool
+}
+
+// newLocker returns a new lock for a goroutine-safe Map, or the fake lock otherwise
+func (option *Options) newLocker() sync.Locker {
+	if option.goroutineSafe {
+		return &gosync.RWMutex{}
+	}
+	return defaultLocker
 }
 
 // Option is a function used to set Options
@@ -32,31 +45,85 @@ func WithKeyComparator(cmp comparator.Comparator) Option {
 
 // WithGoroutineSafe set Map goroutine-safety,
 // Note that iterators are not goroutine safe, and it is useless to turn on the setting option here.
-// so don't use iterators in multi goroutines
+// so don't use iterators in multi goroutines, or use WithThreadSafeIterators together.
 func WithGoroutineSafe() Option {
 	return func(option *Options) {
-		option.locker = &gosync.RWMutex{}
+		option.goroutineSafe = true
+	}
+}
+
+// WithThreadSafeIterators makes Begin, First and Last return iterators holding the read lock of the Map, so that the elements
+// can't be modified by other goroutines during the iteration. The lock is released when the iterator becomes invalid
+// by Next or Prev, or by calling its Close method. It is only useful together with WithGoroutineSafe.
+// Note that writers are blocked until the lock is released, so an iterator which is neither iterated to the end nor closed
+// blocks the writers forever. Calling the writing methods of the Map in the same goroutine before releasing the lock
+// deadlocks, and so may the reading methods when a writer is waiting.
+func WithThreadSafeIterators() Option {
+	return func(option *Options) {
+		option.threadSafeIterators = true
 	}
 }
 
 // Map uses RbTress for internal data structure, and every key can must bee unique.
 type Map struct {
-	tree   *rbtree.RbTree
-	locker sync.Locker
+	tree                *rbtree.RbTree
+	keyCmp              comparator.Comparator
+	locker              sync.Locker
+	threadSafeIterators bool
+	options             Options // the options m is created with, used to create Maps like m
 }
 
 // New new a map
 func New(opts ...Option) *Map {
 	option := Options{
 		keyCmp: defaultKeyComparator,
-		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
+	return newWithOptions(option)
+}
+
+func newWithOptions(option Options) *Map {
 	return &Map{tree: rbtree.New(rbtree.WithKeyComparator(option.keyCmp)),
-		locker: option.locker,
+		keyCmp:              option.keyCmp,
+		locker:              option.newLocker(),
+		threadSafeIterators: option.threadSafeIterators,
+		options:             option,
+	}
+}
+
+// NewFromSorted news a map from keys and their related values in O(n) time,
+// keys must be unique and sorted in ascending order by the key comparator, otherwise it panics.
+func NewFromSorted(keys []interface{}, values []interface{}, opts ...Option) *Map {
+	m := New(opts...)
+	for i := 1; i < len(keys); i++ {
+		if m.keyCmp(keys[i-1], keys[i]) >= 0 {
+			panic(fmt.Sprintf("treemap: keys are not strictly ascending at position %v", i))
+		}
 	}
+	m.tree = rbtree.NewFromSorted(keys, values, rbtree.WithKeyComparator(m.keyCmp))
+	return m
+}
+
+// initZero initializes a zero-value Map, e.g. allocated by encoding/json, with the default options
+func (m *Map) initZero() {
+	if m.tree != nil {
+		return
+	}
+	if m.keyCmp == nil {
+		m.keyCmp = defaultKeyComparator
+	}
+	if m.locker == nil {
+		m.locker = defaultLocker
+	}
+	m.options.keyCmp = m.keyCmp
+	m.tree = rbtree.New(rbtree.WithKeyComparator(m.keyCmp))
+}
+
+// newLike news an empty Map with the same options as m, it has a lock of its own if m is goroutine-safe
+func (m *Map) newLike() *Map {
+	return newWithOptions(m.options)
 }
 
 //Insert inserts key-value to the map
@@ -64,6 +131,28 @@ func (m *Map) Insert(key, value interface{}) {
 	m.locker.Lock()
 	defer m.locker.Unlock()
 
+	m.insert(key, value)
+}
+
+// TryInsert inserts key-value to the map and returns true if the lock of the map can be acquired immediately,
+// otherwise it returns false without blocking.
+// Note that TryLock is supported by sync.RWMutex since go1.18, it blocks until the lock is acquired on lower versions.
+func (m *Map) TryInsert(key, value interface{}) bool {
+	if locker, ok := m.locker.(sync.TryLocker); ok {
+		if !locker.TryLock() {
+			return false
+		}
+	} else {
+		m.locker.Lock()
+	}
+	defer m.locker.Unlock()
+
+	m.insert(key, value)
+	return true
+}
+
+// insert inserts key-value to the map without locking
+func (m *Map) insert(key, value interface{}) {
 	node := m.tree.FindNode(key)
 	if node != nil {
 		node.SetValue(value)
@@ -72,6 +161,21 @@ func (m *Map) Insert(key, value interface{}) {
 	m.tree.Insert(key, value)
 }
 
+// InsertOrAssign inserts key-value to the map if key not exists, otherwise assigns value to the existing key.
+// It returns the iterator related to key, and true if a new element was inserted or false if the value was assigned.
+func (m *Map) InsertOrAssign(key, value interface{}) (*MapIterator, bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		node.SetValue(value)
+		return newMapIterator(node), false
+	}
+	m.tree.Insert(key, value)
+	return newMapIterator(m.tree.FindNode(key)), true
+}
+
 //Get returns the value by key if found, or nil if not found
 func (m *Map) Get(key interface{}) interface{} {
 	m.locker.RLock()
@@ -84,6 +188,110 @@ func (m *Map) Get(key interface{}) interface{} {
 	return nil
 }
 
+// Lookup returns the value by key and true if found, or nil and false if not found.
+// Unlike Get, it tells a missing key from a key whose value is nil.
+func (m *Map) Lookup(key interface{}) (interface{}, bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	node := m.tree.FindNode(key)
+	if node == nil {
+		return nil, false
+	}
+	return node.Value(), true
+}
+
+// ReplaceValue replaces the value of key and returns true if key exists in the map, otherwise it does nothing and returns false
+func (m *Map) ReplaceValue(key, value interface{}) bool {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node == nil {
+		return false
+	}
+	node.SetValue(value)
+	return true
+}
+
+// RenameKey moves the value of oldKey to newKey under one write lock and returns true if oldKey exists in the map,
+// otherwise it does nothing and returns false. If newKey already exists, its value is overwritten.
+func (m *Map) RenameKey(oldKey, newKey interface{}) bool {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(oldKey)
+	if node == nil {
+		return false
+	}
+	if m.keyCmp(oldKey, newKey) == 0 {
+		return true
+	}
+	value := node.Value()
+	m.tree.Delete(node)
+	m.insert(newKey, value)
+	return true
+}
+
+// GetOrInsert returns the value by key if found, otherwise inserts key-defaultValue to the map and returns defaultValue
+func (m *Map) GetOrInsert(key, defaultValue interface{}) interface{} {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		return node.Value()
+	}
+	m.tree.Insert(key, defaultValue)
+	return defaultValue
+}
+
+// GetOrInsertFunc returns the value by key if found, otherwise inserts key with the value returned by fn and returns it.
+// fn is only called when key is not found, and it must not access m.
+func (m *Map) GetOrInsertFunc(key interface{}, fn func() interface{}) interface{} {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		return node.Value()
+	}
+	value := fn()
+	m.tree.Insert(key, value)
+	return value
+}
+
+// Update sets the value of key to the value returned by fn, which is called with the old value and whether key exists.
+// It is done under one write lock, so fn must not access m.
+func (m *Map) Update(key interface{}, fn func(old interface{}, exists bool) interface{}) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node != nil {
+		node.SetValue(fn(node.Value(), true))
+		return
+	}
+	m.tree.Insert(key, fn(nil, false))
+}
+
+// CompareAndSwap sets the value of key to newValue under one write lock and returns true if key exists and its value
+// is equal to expected by equal, otherwise it does nothing and returns false. If equal is nil, reflect.DeepEqual is used.
+func (m *Map) CompareAndSwap(key, expected, newValue interface{}, equal func(a, b interface{}) bool) bool {
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	node := m.tree.FindNode(key)
+	if node == nil || !equal(node.Value(), expected) {
+		return false
+	}
+	node.SetValue(newValue)
+	return true
+}
+
 //Erase erases node by key in the Map
 func (m *Map) Erase(key interface{}) {
 	m.locker.Lock()
@@ -95,6 +303,78 @@ func (m *Map) Erase(key interface{}) {
 	}
 }
 
+//DeleteRange erases all elements with key in range [lo, hi) and returns the number of elements erased
+func (m *Map) DeleteRange(lo, hi interface{}) int {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	count := 0
+	node := m.tree.FindLowerBoundNode(lo)
+	for node != nil && m.keyCmp(node.Key(), hi) < 0 {
+		next := node.Next()
+		m.tree.Delete(node)
+		node = next
+		count++
+	}
+	return count
+}
+
+// EraseIf erases all the elements for which pred returns true and returns the number of erased elements.
+// pred must not access m.
+func (m *Map) EraseIf(pred func(key, value interface{}) bool) int {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	return m.tree.DeleteIf(pred)
+}
+
+//Min returns the element with the minimum key in the Map, ok is false if the Map is empty
+func (m *Map) Min() (key, value interface{}, ok bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return entryOf(m.tree.First())
+}
+
+//Max returns the element with the maximum key in the Map, ok is false if the Map is empty
+func (m *Map) Max() (key, value interface{}, ok bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return entryOf(m.tree.Last())
+}
+
+func entryOf(node *rbtree.Node) (key, value interface{}, ok bool) {
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.Key(), node.Value(), true
+}
+
+//PopFirst removes the element with the minimum key from the Map and returns it, ok is false if the Map is empty
+func (m *Map) PopFirst() (key, value interface{}, ok bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	return m.pop(m.tree.First())
+}
+
+//PopLast removes the element with the maximum key from the Map and returns it, ok is false if the Map is empty
+func (m *Map) PopLast() (key, value interface{}, ok bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	return m.pop(m.tree.Last())
+}
+
+func (m *Map) pop(node *rbtree.Node) (key, value interface{}, ok bool) {
+	key, value, ok = entryOf(node)
+	if ok {
+		m.tree.Delete(node)
+	}
+	return key, value, ok
+}
+
 //EraseIter erases node by iter in the Map
 func (m *Map) EraseIter(iter iterator.ConstKvIterator) {
 	m.locker.Lock()
@@ -102,7 +382,7 @@ func (m *Map) EraseIter(iter iterator.ConstKvIterator) {
 
 	mpIter, ok := iter.(*MapIterator)
 	if ok {
-		m.tree.Delete(mpIter.node)
+		m.tree.Delete(mpIter.cur.Node())
 	}
 }
 
@@ -112,7 +392,7 @@ func (m *Map) Find(key interface{}) *MapIterator {
 	defer m.locker.RUnlock()
 
 	node := m.tree.FindNode(key)
-	return &MapIterator{node: node}
+	return newMapIterator(node)
 }
 
 //LowerBound returns the first iterator that equal or greater than key in the Map
@@ -121,31 +401,89 @@ func (m *Map) LowerBound(key interface{}) *MapIterator {
 	defer m.locker.RUnlock()
 
 	node := m.tree.FindLowerBoundNode(key)
-	return &MapIterator{node: node}
+	return newMapIterator(node)
 }
 
-//Begin returns the iterator with the minimum key in the Map, return nil if empty.
-func (m *Map) Begin() *MapIterator {
+//UpperBound returns the first iterator that greater than key in the Map
+func (m *Map) UpperBound(key interface{}) *MapIterator {
 	m.locker.RLock()
 	defer m.locker.RUnlock()
 
-	return &MapIterator{node: m.tree.First()}
+	node := m.tree.FindUpperBoundNode(key)
+	return newMapIterator(node)
 }
 
-//First returns the iterator with the minimum key in the Map, return nil if empty.
-func (m *Map) First() *MapIterator {
+//EqualRange returns the LowerBound and UpperBound iterators of key in the Map, they make up a half-open range [lower, upper)
+//which contains the element with key if exists
+func (m *Map) EqualRange(key interface{}) (*MapIterator, *MapIterator) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newMapIterator(m.tree.FindLowerBoundNode(key)), newMapIterator(m.tree.FindUpperBoundNode(key))
+}
+
+//Floor returns the iterator with the largest key that equal or less than key in the Map, or an invalid iterator if not exist
+func (m *Map) Floor(key interface{}) *MapIterator {
 	m.locker.RLock()
 	defer m.locker.RUnlock()
 
-	return &MapIterator{node: m.tree.First()}
+	node := m.tree.FindUpperBoundNode(key)
+	if node == nil {
+		return newMapIterator(m.tree.Last())
+	}
+	return newMapIterator(node.Prev())
+}
+
+//Ceiling returns the iterator with the smallest key that equal or greater than key in the Map, or an invalid iterator if not exist.
+//It is the same as LowerBound
+func (m *Map) Ceiling(key interface{}) *MapIterator {
+	return m.LowerBound(key)
+}
+
+// lockedIterator returns an iterator at the node returned by find, which is called under the read lock.
+// If WithThreadSafeIterators is set, the read lock is held by the iterator while it is valid.
+func (m *Map) lockedIterator(find func() *rbtree.Node) *MapIterator {
+	m.locker.RLock()
+	node := find()
+	if !m.threadSafeIterators || node == nil {
+		m.locker.RUnlock()
+		return newMapIterator(node)
+	}
+	return &MapIterator{cur: *rbtree.NewIterator(node), unlock: m.locker.RUnlock}
+}
+
+//Begin returns the iterator with the minimum key in the Map, return nil if empty.
+func (m *Map) Begin() *MapIterator {
+	return m.lockedIterator(func() *rbtree.Node {
+		return m.tree.First()
+	})
+}
+
+//First returns the iterator with the minimum key in the Map, return nil if empty.
+func (m *Map) First() *MapIterator {
+	return m.lockedIterator(func() *rbtree.Node {
+		return m.tree.First()
+	})
 }
 
 //Last returns the iterator with the maximum key in the Map, return nil if empty.
 func (m *Map) Last() *MapIterator {
+	return m.lockedIterator(func() *rbtree.Node {
+		return m.tree.Last()
+	})
+}
+
+//RBegin returns the reverse iterator with the maximum key in the Map, return an invalid iterator if empty.
+func (m *Map) RBegin() *MapReverseIterator {
 	m.locker.RLock()
 	defer m.locker.RUnlock()
 
-	return &MapIterator{node: m.tree.Last()}
+	return newMapReverseIterator(m.tree.Last())
+}
+
+//REnd returns the reverse iterator past the minimum key in the Map, it is always invalid.
+func (m *Map) REnd() *MapReverseIterator {
+	return newMapReverseIterator(nil)
 }
 
 //Clear clears the Map
@@ -156,15 +494,21 @@ func (m *Map) Clear() {
 	m.tree.Clear()
 }
 
-// Contains returns true if key in the Map. otherwise returns false.
+// Reset clears the Map and releases the references of all its nodes in O(n) time, so that the memory of a large Map
+// can be reclaimed promptly even if some iterators of it are still kept. Those iterators must not be used after Reset.
+func (m *Map) Reset() {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.tree.Reset()
+}
+
+// Contains returns true if key in the Map. otherwise returns false, no matter whether the value of key is nil.
 func (m *Map) Contains(key interface{}) bool {
 	m.locker.RLock()
 	defer m.locker.RUnlock()
 
-	if m.tree.Find(key) != nil {
-		return true
-	}
-	return false
+	return m.tree.FindNode(key) != nil
 }
 
 // Size returns the size of Map
@@ -175,6 +519,25 @@ func (m *Map) Size() int {
 	return m.tree.Size()
 }
 
+// IsEmpty returns true if the Map is empty, otherwise returns false
+func (m *Map) IsEmpty() bool {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.tree.Empty()
+}
+
+// Count returns the number of elements with key in the Map, it is 1 if key in the Map, otherwise 0
+func (m *Map) Count(key interface{}) int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if m.tree.FindNode(key) != nil {
+		return 1
+	}
+	return 0
+}
+
 // Traversal traversals elements in map, it will not stop until to the end or visitor returns false
 func (m *Map) Traversal(visitor visitor.KvVisitor) {
 	m.locker.RLock()
@@ -182,3 +545,543 @@ func (m *Map) Traversal(visitor visitor.KvVisitor) {
 
 	m.tree.Traversal(visitor)
 }
+
+// All returns a sequence of the elements in ascending order of keys, it can be used with range-over-func since go1.23:
+//
+//	for k, v := range m.All() { ... }
+//
+// The Map is read-locked until the loop ends, so the Map must not be modified in the loop body.
+func (m *Map) All() func(yield func(key, value interface{}) bool) {
+	return func(yield func(key, value interface{}) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence of the elements in descending order of keys, it can be used with range-over-func since go1.23.
+// The Map is read-locked until the loop ends, so the Map must not be modified in the loop body.
+func (m *Map) Backward() func(yield func(key, value interface{}) bool) {
+	return func(yield func(key, value interface{}) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.Last(); node != nil; node = node.Prev() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// TraversalSnapshot copies all elements of the Map under the read lock, and then traversals the copy without holding the lock,
+// it will not stop until to the end or visitor returns false.
+// So the visitor may take a long time or even modify the Map without blocking writers, but it sees the elements as they were
+// when TraversalSnapshot was called. Note that the copy takes O(n) extra memory.
+func (m *Map) TraversalSnapshot(visitor visitor.KvVisitor) {
+	keys, values := m.Entries()
+	for i := range keys {
+		if !visitor(keys[i], values[i]) {
+			break
+		}
+	}
+}
+
+// WeightedSample returns a random key with probability proportional to its weight returned by weightOf,
+// ok is false if the Map is empty or all the weights are zero. The weights must not be negative, the negative ones are
+// treated as zero. It scans the Map twice and takes O(n) time.
+func (m *Map) WeightedSample(r *rand.Rand, weightOf func(value interface{}) float64) (key interface{}, ok bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	total := 0.0
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if w := weightOf(node.Value()); w > 0 {
+			total += w
+		}
+	}
+	if total <= 0 {
+		return nil, false
+	}
+
+	target := r.Float64() * total
+	var last *rbtree.Node
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		w := weightOf(node.Value())
+		if w <= 0 {
+			continue
+		}
+		last = node
+		if target < w {
+			return node.Key(), true
+		}
+		target -= w
+	}
+	// the rounding errors of the float64 sum may make target run past the last positive weight
+	return last.Key(), true
+}
+
+// Verify checks the invariants of the internal RbTree, it returns an error describing the first violation found,
+// or nil if the Map is consistent. It is intended for tests and takes O(n) time.
+func (m *Map) Verify() error {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.tree.Verify()
+}
+
+// Keys returns all keys in the Map in ascending order
+func (m *Map) Keys() []interface{} {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	keys := make([]interface{}, 0, m.tree.Size())
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		keys = append(keys, node.Key())
+	}
+	return keys
+}
+
+// Values returns all values in the Map in ascending order of their keys
+func (m *Map) Values() []interface{} {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	values := make([]interface{}, 0, m.tree.Size())
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		values = append(values, node.Value())
+	}
+	return values
+}
+
+// Entries returns all keys and values in the Map in ascending order of keys, keys[i] is related to values[i]
+func (m *Map) Entries() ([]interface{}, []interface{}) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	keys := make([]interface{}, 0, m.tree.Size())
+	values := make([]interface{}, 0, m.tree.Size())
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		keys = append(keys, node.Key())
+		values = append(values, node.Value())
+	}
+	return keys, values
+}
+
+// Merge merges the elements of other into m. For each key in other, if the key is not in m, the key-value is inserted,
+// otherwise m stores the value returned by onConflict(existing, incoming).
+// If onConflict is nil, the incoming value overwrites the existing value.
+// Merging m into itself does nothing. onConflict is called with both maps locked, so it must not access them.
+func (m *Map) Merge(other *Map, onConflict func(existing, incoming interface{}) interface{}) {
+	if m == other {
+		return
+	}
+	unlock := lockPair(m, true, other, false)
+	defer unlock()
+
+	for node := other.tree.First(); node != nil; node = node.Next() {
+		existing := m.tree.FindNode(node.Key())
+		if existing == nil {
+			m.tree.Insert(node.Key(), node.Value())
+			continue
+		}
+		if onConflict == nil {
+			existing.SetValue(node.Value())
+		} else {
+			existing.SetValue(onConflict(existing.Value(), node.Value()))
+		}
+	}
+}
+
+// PutAll inserts all the key-values of src to m under a single write lock, the existing values are replaced.
+// Note that the elements are ordered by the key comparator of m regardless of the iteration order of src.
+func (m *Map) PutAll(src map[interface{}]interface{}) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	for key, value := range src {
+		m.insert(key, value)
+	}
+}
+
+// PutAllReflect is like PutAll but accepts any map type such as map[string]int, it panics if src is not a map
+func (m *Map) PutAllReflect(src interface{}) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() != reflect.Map {
+		panic(fmt.Sprintf("treemap: PutAllReflect of non-map type %T", src))
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		m.insert(iter.Key().Interface(), iter.Value().Interface())
+	}
+}
+
+// Equal returns true if m and other have the same keys compared by m's key comparator,
+// and the values of the same key are equal by valueEqual. If valueEqual is nil, reflect.DeepEqual is used.
+// If other's keys are not in ascending order by m's key comparator, they are looked up in m one by one.
+func (m *Map) Equal(other *Map, valueEqual func(a, b interface{}) bool) bool {
+	if valueEqual == nil {
+		valueEqual = reflect.DeepEqual
+	}
+	if m == other {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !valueEqual(node.Value(), node.Value()) {
+				return false
+			}
+		}
+		return true
+	}
+	unlock := lockPair(m, false, other, false)
+	defer unlock()
+
+	if m.tree.Size() != other.tree.Size() {
+		return false
+	}
+	if !m.inKeyOrder(other) {
+		matched := make(map[*rbtree.Node]bool, m.tree.Size())
+		for y := other.tree.First(); y != nil; y = y.Next() {
+			x := m.tree.FindNode(y.Key())
+			if x == nil || matched[x] || !valueEqual(x.Value(), y.Value()) {
+				return false
+			}
+			matched[x] = true
+		}
+		return true
+	}
+	for x, y := m.tree.First(), other.tree.First(); x != nil && y != nil; x, y = x.Next(), y.Next() {
+		if m.keyCmp(x.Key(), y.Key()) != 0 || !valueEqual(x.Value(), y.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// inKeyOrder returns true if the keys of other are strictly ascending by m's key comparator,
+// so that the elements of m and other can be walked together. It is false if their key comparators disagree.
+func (m *Map) inKeyOrder(other *Map) bool {
+	var prev *rbtree.Node
+	for node := other.tree.First(); node != nil; node = node.Next() {
+		if prev != nil && m.keyCmp(prev.Key(), node.Key()) >= 0 {
+			return false
+		}
+		prev = node
+	}
+	return true
+}
+
+// Diff compares m with other by m's key comparator and returns the differences as three new Maps:
+// added holds the elements whose keys are in other but not in m, removed holds the elements whose keys are in m but not in other,
+// and changed holds the elements of other whose keys are in both but the values are not equal by valueEqual.
+// If valueEqual is nil, reflect.DeepEqual is used. It walks both maps once and takes O(n+m) time,
+// or O(m*log(n)) time if other's keys are not in ascending order by m's key comparator and have to be looked up in m.
+// The new Maps have the same key comparator and goroutine-safety as m.
+func (m *Map) Diff(other *Map, valueEqual func(a, b interface{}) bool) (added, removed, changed *Map) {
+	added, removed, changed = m.newLike(), m.newLike(), m.newLike()
+	if valueEqual == nil {
+		valueEqual = reflect.DeepEqual
+	}
+	if m == other {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		var changedEntries sortedEntries
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !valueEqual(node.Value(), node.Value()) {
+				changedEntries.append(node)
+			}
+		}
+		changed.tree = changedEntries.build(m.keyCmp)
+		return added, removed, changed
+	}
+	unlock := lockPair(m, false, other, false)
+	defer unlock()
+
+	if !m.inKeyOrder(other) {
+		m.diffByLookup(other, valueEqual, added, removed, changed)
+		return added, removed, changed
+	}
+	var addedEntries, removedEntries, changedEntries sortedEntries
+	x, y := m.tree.First(), other.tree.First()
+	for x != nil || y != nil {
+		var c int
+		if x == nil {
+			c = 1
+		} else if y == nil {
+			c = -1
+		} else {
+			c = m.keyCmp(x.Key(), y.Key())
+		}
+		switch {
+		case c < 0:
+			removedEntries.append(x)
+			x = x.Next()
+		case c > 0:
+			addedEntries.append(y)
+			y = y.Next()
+		default:
+			if !valueEqual(x.Value(), y.Value()) {
+				changedEntries.append(y)
+			}
+			x, y = x.Next(), y.Next()
+		}
+	}
+	added.tree = addedEntries.build(m.keyCmp)
+	removed.tree = removedEntries.build(m.keyCmp)
+	changed.tree = changedEntries.build(m.keyCmp)
+	return added, removed, changed
+}
+
+// diffByLookup is Diff for the maps whose key comparators disagree, it looks up every key of other in m.
+func (m *Map) diffByLookup(other *Map, valueEqual func(a, b interface{}) bool, added, removed, changed *Map) {
+	matched := make(map[*rbtree.Node]bool)
+	for y := other.tree.First(); y != nil; y = y.Next() {
+		x := m.tree.FindNode(y.Key())
+		if x == nil {
+			added.insert(y.Key(), y.Value())
+			continue
+		}
+		matched[x] = true
+		if !valueEqual(x.Value(), y.Value()) {
+			changed.insert(y.Key(), y.Value())
+		}
+	}
+	var removedEntries sortedEntries
+	for x := m.tree.First(); x != nil; x = x.Next() {
+		if !matched[x] {
+			removedEntries.append(x)
+		}
+	}
+	removed.tree = removedEntries.build(m.keyCmp)
+}
+
+// sortedEntries collects the elements in ascending order of keys to build a RbTree
+type sortedEntries struct {
+	keys   []interface{}
+	values []interface{}
+}
+
+func (e *sortedEntries) append(node *rbtree.Node) {
+	e.keys = append(e.keys, node.Key())
+	e.values = append(e.values, node.Value())
+}
+
+func (e *sortedEntries) build(keyCmp comparator.Comparator) *rbtree.RbTree {
+	return rbtree.NewFromSorted(e.keys, e.values, rbtree.WithKeyComparator(keyCmp))
+}
+
+// Swap exchanges the elements and key comparators of m and other in O(1) time, their goroutine-safety options are not exchanged.
+func (m *Map) Swap(other *Map) {
+	if m == other {
+		return
+	}
+	unlock := lockPair(m, true, other, true)
+	defer unlock()
+
+	m.tree, other.tree = other.tree, m.tree
+	m.keyCmp, other.keyCmp = other.keyCmp, m.keyCmp
+	m.options.keyCmp, other.options.keyCmp = other.options.keyCmp, m.options.keyCmp
+}
+
+// lockPair locks two different maps in the order of their addresses, so that goroutines locking
+// the same pair of maps in opposite order can't deadlock. It returns a function to unlock them.
+func lockPair(a *Map, aWrite bool, b *Map, bWrite bool) func() {
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+		aWrite, bWrite = bWrite, aWrite
+	}
+	lock(a.locker, aWrite)
+	lock(b.locker, bWrite)
+	return func() {
+		unlock(b.locker, bWrite)
+		unlock(a.locker, aWrite)
+	}
+}
+
+func lock(locker sync.Locker, write bool) {
+	if write {
+		locker.Lock()
+	} else {
+		locker.RLock()
+	}
+}
+
+func unlock(locker sync.Locker, write bool) {
+	if write {
+		locker.Unlock()
+	} else {
+		locker.RUnlock()
+	}
+}
+
+// Clone returns a copy of m with the same key comparator and goroutine-safety.
+// The internal tree is copied in O(n) time, and the keys and values are shallow copied.
+func (m *Map) Clone() *Map {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	c := m.newLike()
+	c.tree = m.tree.Clone()
+	return c
+}
+
+// Filter returns a new Map with the elements for which pred returns true, m is not changed.
+// The new Map has the same key comparator and goroutine-safety as m. pred must not access m.
+func (m *Map) Filter(pred func(key, value interface{}) bool) *Map {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	keys := make([]interface{}, 0)
+	values := make([]interface{}, 0)
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if pred(node.Key(), node.Value()) {
+			keys = append(keys, node.Key())
+			values = append(values, node.Value())
+		}
+	}
+	c := m.newLike()
+	c.tree = rbtree.NewFromSorted(keys, values, rbtree.WithKeyComparator(m.keyCmp))
+	return c
+}
+
+// Partition splits the elements of m into two new Maps in one pass: matching with the elements for which pred returns true,
+// and rest with the others, m is not changed. The new Maps have the same key comparator and goroutine-safety as m.
+// pred must not access m.
+func (m *Map) Partition(pred func(key, value interface{}) bool) (matching, rest *Map) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	var yes, no sortedEntries
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if pred(node.Key(), node.Value()) {
+			yes.append(node)
+		} else {
+			no.append(node)
+		}
+	}
+	matching, rest = m.newLike(), m.newLike()
+	matching.tree = yes.build(m.keyCmp)
+	rest.tree = no.build(m.keyCmp)
+	return matching, rest
+}
+
+// MapValues returns a new Map with the same keys as m and the values returned by fn, m is not changed.
+// The new Map has the same key comparator and goroutine-safety as m. fn must not access m.
+func (m *Map) MapValues(fn func(key, value interface{}) interface{}) *Map {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	c := m.newLike()
+	c.tree = m.tree.Clone()
+	for node := c.tree.First(); node != nil; node = node.Next() {
+		node.SetValue(fn(node.Key(), node.Value()))
+	}
+	return c
+}
+
+// ForEach calls fn for every element in the Map in ascending order of keys.
+// Note that the Map is read-locked during the iteration, so fn must not modify the Map.
+func (m *Map) ForEach(fn func(key, value interface{})) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	m.tree.Traversal(func(key, value interface{}) bool {
+		fn(key, value)
+		return true
+	})
+}
+
+// ForEachIf calls fn for the elements in the Map in ascending order of keys until fn returns false.
+// Note that the Map is read-locked during the iteration, so fn must not modify the Map.
+func (m *Map) ForEachIf(fn func(key, value interface{}) bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	m.tree.Traversal(fn)
+}
+
+// Range calls fn for the elements with lo <= key < hi in ascending order of keys until fn returns false.
+// A nil lo means from the minimum key, and a nil hi means to the maximum key, Unbounded can be used instead of nil.
+// Note that the Map is read-locked during the iteration, so fn must not modify the Map.
+func (m *Map) Range(lo, hi interface{}, fn func(key, value interface{}) bool) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	node := m.tree.First()
+	if !isOpen(lo) {
+		node = m.tree.FindLowerBoundNode(lo)
+	}
+	for ; node != nil; node = node.Next() {
+		if !isOpen(hi) && m.keyCmp(node.Key(), hi) >= 0 {
+			break
+		}
+		if !fn(node.Key(), node.Value()) {
+			break
+		}
+	}
+}
+
+type unbounded struct{}
+
+// Unbounded can be passed as lo or hi to the range methods instead of nil to leave that side of the range open explicitly.
+// Note that a nil bound is always open, even if nil keys are allowed by the key comparator, e.g. comparator.NullsFirst.
+var Unbounded interface{} = unbounded{}
+
+// isOpen returns true if bound leaves its side of a range open, that is if it is nil or Unbounded
+func isOpen(bound interface{}) bool {
+	return bound == nil || bound == Unbounded
+}
+
+// CountRange returns the number of elements with lo <= key < hi without iterating them, it takes O(log n) time.
+// A nil lo means from the minimum key, and a nil hi means to the maximum key, Unbounded can be used instead of nil.
+// It returns 0 if lo >= hi.
+func (m *Map) CountRange(lo, hi interface{}) int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.countRange(lo, hi)
+}
+
+func (m *Map) countRange(lo, hi interface{}) int {
+	if !isOpen(lo) && !isOpen(hi) && m.keyCmp(lo, hi) >= 0 {
+		return 0
+	}
+	begin, end := 0, m.tree.Size()
+	if !isOpen(lo) {
+		begin = m.tree.Rank(lo)
+	}
+	if !isOpen(hi) {
+		end = m.tree.Rank(hi)
+	}
+	return end - begin
+}
+
+// RangeValues returns the values of the elements with lo <= key < hi in ascending order of keys.
+// A nil lo means from the minimum key, and a nil hi means to the maximum key, Unbounded can be used instead of nil.
+func (m *Map) RangeValues(lo, hi interface{}) []interface{} {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	values := make([]interface{}, 0, m.countRange(lo, hi))
+	if cap(values) == 0 {
+		return values
+	}
+	node := m.tree.First()
+	if !isOpen(lo) {
+		node = m.tree.FindLowerBoundNode(lo)
+	}
+	for ; node != nil && len(values) < cap(values); node = node.Next() {
+		values = append(values, node.Value())
+	}
+	return values
+}
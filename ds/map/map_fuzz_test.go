@@ -0,0 +1,82 @@
+//go:build go1.18
+// +build go1.18
+
+package treemap
+
+import (
+	"sort"
+	"testing"
+)
+
+// FuzzMapDifferential applies the operations encoded in data to a Map and a builtin map, and checks that
+// they stay consistent and that the internal RbTree stays valid. Every 2 bytes encode an operation and a key.
+// Run it with: go test -fuzz FuzzMapDifferential ./ds/map
+func FuzzMapDifferential(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 2, 2, 3})
+	f.Add([]byte{0, 5, 0, 5, 3, 0, 0, 200, 4, 100, 1, 200})
+	seed := make([]byte, 0, 512)
+	for i := 0; i < 256; i++ {
+		seed = append(seed, byte(i%3), byte(i*37))
+	}
+	f.Add(seed)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m := New()
+		ref := make(map[int]int)
+		for i := 0; i+1 < len(data); i += 2 {
+			key := int(data[i+1])
+			switch data[i] % 5 {
+			case 0:
+				m.Insert(key, i)
+				ref[key] = i
+			case 1:
+				m.Erase(key)
+				delete(ref, key)
+			case 2:
+				value, ok := ref[key]
+				if m.Contains(key) != ok || (ok && m.Get(key) != value) {
+					t.Fatalf("Get(%v) = %v, expect %v", key, m.Get(key), value)
+				}
+			case 3:
+				first, _, ok := m.PopFirst()
+				if ok != (len(ref) > 0) {
+					t.Fatalf("PopFirst returns %v with %v elements", ok, len(ref))
+				}
+				if ok {
+					delete(ref, first.(int))
+				}
+			case 4:
+				hi := key + 16
+				removed := m.DeleteRange(key, hi)
+				for k := key; k < hi; k++ {
+					if _, ok := ref[k]; ok {
+						delete(ref, k)
+						removed--
+					}
+				}
+				if removed != 0 {
+					t.Fatalf("DeleteRange(%v, %v) removes a wrong number of elements", key, hi)
+				}
+			}
+			if err := m.Verify(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		keys := make([]int, 0, len(ref))
+		for key := range ref {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		if m.Size() != len(keys) {
+			t.Fatalf("Size() = %v, expect %v", m.Size(), len(keys))
+		}
+		i := 0
+		for iter := m.Begin(); iter.IsValid(); iter.Next() {
+			if iter.Key() != keys[i] || iter.Value() != ref[keys[i]] {
+				t.Fatalf("element %v is %v:%v, expect %v:%v", i, iter.Key(), iter.Value(), keys[i], ref[keys[i]])
+			}
+			i++
+		}
+	})
+}
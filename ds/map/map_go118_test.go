@@ -0,0 +1,57 @@
+//go:build go1.18
+// +build go1.18
+
+package treemap
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMapTryInsert(t *testing.T) {
+	m := New()
+	assert.True(t, m.TryInsert(1, 1))
+	assert.Equal(t, 1, m.Get(1))
+
+	m = New(WithGoroutineSafe())
+	assert.True(t, m.TryInsert(1, 1))
+
+	m.locker.RLock()
+	assert.False(t, m.TryInsert(2, 2))
+	m.locker.RUnlock()
+
+	m.locker.Lock()
+	assert.False(t, m.TryInsert(2, 2))
+	m.locker.Unlock()
+
+	assert.True(t, m.TryInsert(2, 2))
+	assert.Equal(t, []interface{}{1, 2}, m.Keys())
+}
+
+func TestMapTypedIterator(t *testing.T) {
+	m := New()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	sum := 0
+	for iter := iterator.Typed[int](m.Begin()); iter.IsValid(); iter.Next() {
+		sum += iter.Value()
+	}
+	assert.Equal(t, 450, sum)
+
+	var _ iterator.TypedIterator[int] = iterator.Typed[int](m.Begin())
+
+	// the adapter is only a convenience, it neither adds nor saves allocations
+	plain := testing.AllocsPerRun(10, func() {
+		for iter := m.Begin(); iter.IsValid(); iter.Next() {
+			sum += iter.Value().(int)
+		}
+	})
+	typed := testing.AllocsPerRun(10, func() {
+		for iter := iterator.Typed[int](m.Begin()); iter.IsValid(); iter.Next() {
+			sum += iter.Value()
+		}
+	})
+	assert.Equal(t, plain, typed)
+}
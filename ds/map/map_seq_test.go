@@ -0,0 +1,73 @@
+//go:build go1.23
+// +build go1.23
+
+package treemap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMapAll(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+
+	i := 0
+	for k, v := range m.All() {
+		assert.Equal(t, i, k)
+		assert.Equal(t, i*10, v)
+		i++
+	}
+	assert.Equal(t, 10, i)
+
+	i = 9
+	for k, v := range m.Backward() {
+		assert.Equal(t, i, k)
+		assert.Equal(t, i*10, v)
+		i--
+	}
+	assert.Equal(t, -1, i)
+
+	for range New().All() {
+		t.Fatal("unexpected element")
+	}
+}
+
+func TestMapAllBreak(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+	}
+
+	var keys []interface{}
+	for k := range m.All() {
+		if k.(int) == 3 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []interface{}{0, 1, 2}, keys)
+
+	keys = nil
+	for k := range m.Backward() {
+		keys = append(keys, k)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []interface{}{9, 8}, keys)
+
+	// the lock is released after break, so the Map can be modified
+	m.Insert(10, 10)
+	assert.Equal(t, 11, m.Size())
+
+	// the sequences can be used without range-over-func too
+	visited := 0
+	m.All()(func(key, value interface{}) bool {
+		visited++
+		return visited < 5
+	})
+	assert.Equal(t, 5, visited)
+}
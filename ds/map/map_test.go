@@ -1,8 +1,15 @@
 package treemap
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestMap(t *testing.T) {
@@ -26,6 +33,39 @@ func TestMap(t *testing.T) {
 	assert.Equal(t, 0, m.Size())
 }
 
+func TestMapLookup(t *testing.T) {
+	m := New()
+	_, ok := m.Lookup(1)
+	assert.False(t, ok)
+	m.Insert(1, nil)
+	m.Insert(2, "b")
+	value, ok := m.Lookup(1)
+	assert.True(t, ok)
+	assert.Nil(t, value)
+	value, ok = m.Lookup(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", value)
+}
+
+func TestMapReset(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+	iter := m.Find(50)
+	m.Reset()
+	assert.Equal(t, 0, m.Size())
+	assert.False(t, m.Contains(50))
+	// the kept iterator no longer references the data of the Map
+	assert.Nil(t, iter.cur.Node().Key())
+	assert.Nil(t, iter.cur.Node().Value())
+	assert.Nil(t, iter.cur.Node().Next())
+
+	m.Insert(1, 1)
+	assert.Equal(t, 1, m.Get(1))
+	assert.Nil(t, m.Verify())
+}
+
 func TestMapIterator(t *testing.T) {
 	m := New(WithGoroutineSafe())
 
@@ -75,6 +115,23 @@ func TestMapIteratorSetValue(t *testing.T) {
 	assert.Equal(t, "ccc", m.Get(1))
 }
 
+func TestMapIteratorSetValueDuringTraversal(t *testing.T) {
+	m := New()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+	}
+	for iter := m.First(); iter.IsValid(); iter.Next() {
+		assert.Nil(t, iter.SetValue(iter.Value().(int)*2))
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i*2, m.Get(i))
+	}
+
+	iter := m.Find(100)
+	assert.Equal(t, ErrInvalidIterator, iter.SetValue(1))
+	assert.Equal(t, ErrInvalidIterator, New().RBegin().SetValue(1))
+}
+
 func TestMap_Traversal(t *testing.T) {
 	m := New()
 
@@ -90,3 +147,1287 @@ func TestMap_Traversal(t *testing.T) {
 		return true
 	})
 }
+
+func TestMapReverseIterator(t *testing.T) {
+	m := New()
+	assert.False(t, m.RBegin().IsValid())
+	assert.True(t, m.RBegin().Equal(m.REnd()))
+
+	m.Insert(1, 100)
+	iter := m.RBegin()
+	assert.True(t, iter.IsValid())
+	assert.Equal(t, 1, iter.Key())
+	assert.Equal(t, 100, iter.Value())
+	iter.Next()
+	assert.False(t, iter.IsValid())
+
+	for i := 2; i <= 10; i++ {
+		m.Insert(i, i*100)
+	}
+	i := 10
+	for iter := m.RBegin(); !iter.Equal(m.REnd()); iter.Next() {
+		assert.Equal(t, i, iter.Key())
+		assert.Equal(t, i*100, iter.Value())
+		i--
+	}
+	assert.Equal(t, 0, i)
+
+	iter = m.RBegin()
+	iter.Next()
+	iter.Prev()
+	assert.Equal(t, 10, iter.Key())
+}
+
+func TestMapUpperBound(t *testing.T) {
+	m := New()
+	assert.False(t, m.UpperBound(1).IsValid())
+
+	for i := 1; i <= 9; i += 2 {
+		m.Insert(i, i*10)
+	}
+	assert.True(t, m.UpperBound(0).Equal(m.Begin()))
+	assert.Equal(t, 3, m.UpperBound(1).Key())
+	assert.Equal(t, 3, m.UpperBound(2).Key())
+	assert.Equal(t, 30, m.UpperBound(2).Value())
+	assert.False(t, m.UpperBound(9).IsValid())
+	assert.False(t, m.UpperBound(10).IsValid())
+
+	m = New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 1; i <= 9; i += 2 {
+		m.Insert(i, i*10)
+	}
+	assert.Equal(t, 9, m.UpperBound(10).Key())
+	assert.Equal(t, 3, m.UpperBound(5).Key())
+	assert.Equal(t, 3, m.UpperBound(4).Key())
+	assert.False(t, m.UpperBound(1).IsValid())
+}
+
+func TestMapKeysValues(t *testing.T) {
+	m := New()
+	assert.NotNil(t, m.Keys())
+	assert.Equal(t, 0, len(m.Keys()))
+	assert.NotNil(t, m.Values())
+	assert.Equal(t, 0, len(m.Values()))
+
+	for i := 5; i >= 1; i-- {
+		m.Insert(i, i*10)
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, m.Keys())
+	assert.Equal(t, []interface{}{10, 20, 30, 40, 50}, m.Values())
+
+	keys, values := m.Entries()
+	assert.Equal(t, m.Keys(), keys)
+	assert.Equal(t, m.Values(), values)
+}
+
+func TestMapJSON(t *testing.T) {
+	type person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	m := New()
+	m.Insert("bob", person{Name: "Bob", Age: 30})
+	m.Insert("alice", person{Name: "Alice", Age: 20})
+
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"alice":{"name":"Alice","age":20},"bob":{"name":"Bob","age":30}}`, string(data))
+
+	other := New()
+	other.Insert("carol", 1)
+	assert.Nil(t, json.Unmarshal(data, other))
+	assert.Equal(t, []interface{}{"alice", "bob"}, other.Keys())
+	assert.Equal(t, map[string]interface{}{"name": "Bob", "age": float64(30)}, other.Get("bob"))
+
+	m = New()
+	for i := 3; i >= 1; i-- {
+		m.Insert(float64(i), fmt.Sprintf("v%d", i))
+	}
+	data, err = json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"key":1,"value":"v1"},{"key":2,"value":"v2"},{"key":3,"value":"v3"}]`, string(data))
+
+	other = New(WithKeyComparator(comparator.Reverse(comparator.Float64Comparator)))
+	assert.Nil(t, json.Unmarshal(data, other))
+	assert.Equal(t, []interface{}{float64(3), float64(2), float64(1)}, other.Keys())
+	assert.Equal(t, "v2", other.Get(float64(2)))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`[1,`), other))
+}
+
+func TestMapJSONField(t *testing.T) {
+	type wrapper struct {
+		M *Map
+	}
+	src := wrapper{M: New()}
+	src.M.Insert("b", 2.0)
+	src.M.Insert("a", 1.0)
+	data, err := json.Marshal(src)
+	assert.Nil(t, err)
+
+	var dst wrapper
+	assert.Nil(t, json.Unmarshal(data, &dst))
+	assert.Equal(t, []interface{}{"a", "b"}, dst.M.Keys())
+	assert.Equal(t, 2.0, dst.M.Get("b"))
+	dst.M.Insert("c", 3.0)
+	assert.Equal(t, 3, dst.M.Size())
+
+	// a zero-value Map works as well
+	var m Map
+	assert.Nil(t, json.Unmarshal([]byte(`[{"key":2,"value":"x"},{"key":1,"value":"y"}]`), &m))
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, m.Keys())
+}
+
+func TestMapMerge(t *testing.T) {
+	m := New()
+	other := New()
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+		other.Insert(i+3, (i+3)*10)
+	}
+
+	m.Merge(other, nil)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4, 5, 6, 7}, m.Keys())
+	assert.Equal(t, []interface{}{0, 1, 2, 30, 40, 50, 60, 70}, m.Values())
+	assert.Equal(t, 5, other.Size())
+
+	m = New()
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+	m.Merge(other, func(existing, incoming interface{}) interface{} {
+		return existing.(int) + incoming.(int)
+	})
+	assert.Equal(t, []interface{}{0, 1, 2, 33, 44, 50, 60, 70}, m.Values())
+
+	m.Merge(m, nil)
+	assert.Equal(t, 8, m.Size())
+}
+
+func TestMapMergeConcurrent(t *testing.T) {
+	a := New(WithGoroutineSafe())
+	b := New(WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		a.Insert(i, i)
+		b.Insert(i+50, i)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.Merge(b, nil)
+		}()
+		go func() {
+			defer wg.Done()
+			b.Merge(a, nil)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 150, a.Size())
+	assert.Equal(t, 150, b.Size())
+}
+
+func TestMapClone(t *testing.T) {
+	m := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	c := m.Clone()
+	assert.Equal(t, m.Keys(), c.Keys())
+	assert.Equal(t, m.Values(), c.Values())
+
+	c.Erase(5)
+	c.Insert(20, 200)
+	c.Insert(1, 100)
+	assert.Equal(t, 10, m.Size())
+	assert.True(t, m.Contains(5))
+	assert.False(t, m.Contains(20))
+	assert.Equal(t, 10, m.Get(1))
+	assert.Equal(t, 20, c.Begin().Key())
+}
+
+func TestMapEqualRange(t *testing.T) {
+	m := New()
+	lower, upper := m.EqualRange(1)
+	assert.False(t, lower.IsValid())
+	assert.False(t, upper.IsValid())
+
+	for i := 0; i < 10; i += 2 {
+		m.Insert(i, i*10)
+	}
+
+	lower, upper = m.EqualRange(4)
+	assert.Equal(t, 4, lower.Key())
+	assert.Equal(t, 6, upper.Key())
+	count := 0
+	for iter := lower; !iter.Equal(upper); iter.Next() {
+		assert.Equal(t, 40, iter.Value())
+		count++
+	}
+	assert.Equal(t, 1, count)
+
+	lower, upper = m.EqualRange(5)
+	assert.Equal(t, 6, lower.Key())
+	assert.True(t, lower.Equal(upper))
+
+	lower, upper = m.EqualRange(8)
+	assert.Equal(t, 8, lower.Key())
+	assert.False(t, upper.IsValid())
+
+	lower, upper = m.EqualRange(9)
+	assert.False(t, lower.IsValid())
+	assert.True(t, lower.Equal(upper))
+}
+
+func TestMapGetOrInsert(t *testing.T) {
+	m := New()
+	assert.Equal(t, 1, m.GetOrInsert("a", 1))
+	assert.Equal(t, 1, m.GetOrInsert("a", 2))
+	assert.Equal(t, 1, m.Get("a"))
+
+	called := 0
+	fn := func() interface{} {
+		called++
+		return 3
+	}
+	assert.Equal(t, 3, m.GetOrInsertFunc("b", fn))
+	assert.Equal(t, 3, m.GetOrInsertFunc("b", fn))
+	assert.Equal(t, 1, m.GetOrInsertFunc("a", fn))
+	assert.Equal(t, 1, called)
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestMapGetOrInsertConcurrent(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	var mu sync.Mutex
+	inserted := 0
+
+	wg := sync.WaitGroup{}
+	values := make([]interface{}, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i] = m.GetOrInsertFunc("key", func() interface{} {
+				mu.Lock()
+				defer mu.Unlock()
+				inserted++
+				return i
+			})
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, inserted)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, m.Get("key"), values[i])
+	}
+}
+
+func TestMapForEach(t *testing.T) {
+	m := New()
+	for i := 5; i >= 1; i-- {
+		m.Insert(i, i*10)
+	}
+
+	var keys []interface{}
+	m.ForEach(func(key, value interface{}) {
+		assert.Equal(t, key.(int)*10, value)
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, keys)
+
+	keys = nil
+	m.ForEachIf(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return key.(int) < 3
+	})
+	assert.Equal(t, []interface{}{1, 2, 3}, keys)
+}
+
+func TestMapEqual(t *testing.T) {
+	m1 := New()
+	m2 := New(WithGoroutineSafe())
+	assert.True(t, m1.Equal(m2, nil))
+
+	for i := 0; i < 5; i++ {
+		m1.Insert(i, []int{i})
+		m2.Insert(4-i, []int{4 - i})
+	}
+	assert.True(t, m1.Equal(m2, nil))
+	assert.True(t, m2.Equal(m1, nil))
+	assert.True(t, m1.Equal(m1, nil))
+	assert.False(t, m1.Equal(m2, func(a, b interface{}) bool {
+		return false
+	}))
+
+	m2.Insert(5, []int{5})
+	assert.False(t, m1.Equal(m2, nil))
+	assert.False(t, m2.Equal(m1, nil))
+
+	m2.Erase(5)
+	m2.Erase(0)
+	m2.Insert(10, []int{0})
+	assert.False(t, m1.Equal(m2, nil))
+
+	m2.Erase(10)
+	m2.Insert(0, []int{1})
+	assert.False(t, m1.Equal(m2, nil))
+	assert.True(t, m1.Equal(m2, func(a, b interface{}) bool {
+		return len(a.([]int)) == len(b.([]int))
+	}))
+
+	// valueEqual is called when comparing a Map with itself
+	assert.False(t, m1.Equal(m1, func(a, b interface{}) bool {
+		return false
+	}))
+}
+
+func TestMapEqualDifferentComparators(t *testing.T) {
+	asc := New()
+	desc := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 0; i < 5; i++ {
+		asc.Insert(i, i*10)
+		desc.Insert(i, i*10)
+	}
+	assert.True(t, asc.Equal(desc, nil))
+	assert.True(t, desc.Equal(asc, nil))
+
+	desc.Insert(3, 0)
+	assert.False(t, asc.Equal(desc, nil))
+	assert.False(t, desc.Equal(asc, nil))
+
+	desc.Erase(3)
+	desc.Insert(7, 30)
+	assert.False(t, asc.Equal(desc, nil))
+
+	// keys which are different in other but the same by m's key comparator
+	mod := New(WithKeyComparator(func(a, b interface{}) int {
+		return comparator.IntComparator(a.(int)%2, b.(int)%2)
+	}))
+	mod.Insert(0, 0)
+	mod.Insert(1, 1)
+	pair := New()
+	pair.Insert(0, 0)
+	pair.Insert(2, 0)
+	assert.False(t, mod.Equal(pair, nil))
+}
+
+func TestNewFromSorted(t *testing.T) {
+	m := NewFromSorted(nil, nil)
+	assert.Equal(t, 0, m.Size())
+
+	keys := []interface{}{"a", "b", "c", "d"}
+	values := []interface{}{1, 2, 3, 4}
+	m = NewFromSorted(keys, values, WithGoroutineSafe())
+	assert.Equal(t, keys, m.Keys())
+	assert.Equal(t, values, m.Values())
+	m.Insert("e", 5)
+	m.Insert("a", 0)
+	assert.Equal(t, 0, m.Get("a"))
+	assert.Equal(t, 5, m.Size())
+
+	assert.Panics(t, func() { NewFromSorted([]interface{}{1, 1}, []interface{}{1, 2}) })
+	assert.Panics(t, func() { NewFromSorted([]interface{}{2, 1}, []interface{}{1, 2}) })
+	assert.Panics(t, func() { NewFromSorted([]interface{}{1, 2}, []interface{}{1}) })
+	assert.NotPanics(t, func() {
+		NewFromSorted([]interface{}{2, 1}, []interface{}{1, 2}, WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	})
+}
+
+func sortedKeys(n int) []interface{} {
+	keys := make([]interface{}, n)
+	for i := range keys {
+		keys[i] = i
+	}
+	return keys
+}
+
+func BenchmarkNewFromSorted(b *testing.B) {
+	keys := sortedKeys(1000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewFromSorted(keys, keys)
+	}
+}
+
+func BenchmarkInsertSorted(b *testing.B) {
+	keys := sortedKeys(1000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := New()
+		for _, key := range keys {
+			m.Insert(key, key)
+		}
+	}
+}
+
+func TestMapIsEmptyCount(t *testing.T) {
+	m := New()
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, 0, m.Count(1))
+
+	m.Insert(1, nil)
+	m.Insert(2, 2)
+	assert.False(t, m.IsEmpty())
+	assert.Equal(t, 1, m.Count(1))
+	assert.Equal(t, 1, m.Count(2))
+	assert.Equal(t, 0, m.Count(3))
+
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestMapInsertOrAssign(t *testing.T) {
+	m := New()
+	iter, inserted := m.InsertOrAssign(1, "a")
+	assert.True(t, inserted)
+	assert.Equal(t, 1, iter.Key())
+	assert.Equal(t, "a", iter.Value())
+
+	iter, inserted = m.InsertOrAssign(2, "b")
+	assert.True(t, inserted)
+	assert.Equal(t, 2, iter.Key())
+
+	iter, inserted = m.InsertOrAssign(1, "c")
+	assert.False(t, inserted)
+	assert.Equal(t, 1, iter.Key())
+	assert.Equal(t, "c", iter.Value())
+	assert.Equal(t, "c", m.Get(1))
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, 2, iter.Next().(*MapIterator).Key())
+}
+
+func TestMapRange(t *testing.T) {
+	m := New()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	collect := func(lo, hi interface{}) []interface{} {
+		keys := []interface{}{}
+		m.Range(lo, hi, func(key, value interface{}) bool {
+			assert.Equal(t, key.(int)*10, value)
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+
+	assert.Equal(t, []interface{}{3, 4, 5}, collect(3, 6))
+	assert.Equal(t, []interface{}{}, collect(3, 3))
+	assert.Equal(t, []interface{}{}, collect(6, 3))
+	assert.Equal(t, []interface{}{}, collect(20, 30))
+	assert.Equal(t, []interface{}{0, 1}, collect(nil, 2))
+	assert.Equal(t, []interface{}{8, 9}, collect(8, nil))
+	assert.Equal(t, 10, len(collect(nil, nil)))
+	assert.Equal(t, []interface{}{0, 1}, collect(Unbounded, 2))
+	assert.Equal(t, []interface{}{8, 9}, collect(8, Unbounded))
+	assert.Equal(t, 10, len(collect(Unbounded, nil)))
+	assert.Equal(t, 10, len(collect(-5, 100)))
+
+	var keys []interface{}
+	m.Range(2, 8, func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []interface{}{2, 3}, keys)
+
+	// nil bounds are open even if the key comparator allows nil keys
+	m = New(WithKeyComparator(comparator.NullsFirst(comparator.IntComparator)))
+	m.Insert(nil, "nil")
+	m.Insert(1, 10)
+	m.Insert(2, 20)
+	collectKeys := func(lo, hi interface{}) []interface{} {
+		keys := []interface{}{}
+		m.Range(lo, hi, func(key, value interface{}) bool {
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+	assert.Equal(t, []interface{}{nil, 1}, collectKeys(nil, 2))
+	assert.Equal(t, []interface{}{nil, 1, 2}, collectKeys(nil, nil))
+	assert.Equal(t, []interface{}{1, 2}, collectKeys(1, nil))
+}
+
+func TestMapNewLike(t *testing.T) {
+	m := New(WithGoroutineSafe(), WithThreadSafeIterators(), WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	c := m.newLike()
+	assert.True(t, c.options.goroutineSafe)
+	assert.True(t, c.threadSafeIterators)
+	assert.NotNil(t, c.locker)
+	assert.True(t, c.locker != m.locker)
+	c.Insert(1, 1)
+	c.Insert(2, 2)
+	assert.Equal(t, []interface{}{2, 1}, c.Keys())
+
+	c = New().newLike()
+	assert.Equal(t, defaultLocker, c.locker)
+	assert.False(t, c.threadSafeIterators)
+
+	var zero Map
+	zero.initZero()
+	c = zero.newLike()
+	c.Insert(1, 1)
+	assert.Equal(t, 1, c.Get(1))
+}
+
+func TestMapSwap(t *testing.T) {
+	a := New(WithGoroutineSafe())
+	b := New(WithGoroutineSafe(), WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 0; i < 3; i++ {
+		a.Insert(i, i)
+	}
+	for i := 0; i < 5; i++ {
+		b.Insert(i, i*10)
+	}
+
+	a.Swap(b)
+	assert.Equal(t, 5, a.Size())
+	assert.Equal(t, 3, b.Size())
+	assert.Equal(t, []interface{}{4, 3, 2, 1, 0}, a.Keys())
+	assert.Equal(t, []interface{}{40, 30, 20, 10, 0}, a.Values())
+	assert.Equal(t, []interface{}{0, 1, 2}, b.Keys())
+
+	a.Swap(a)
+	assert.Equal(t, 5, a.Size())
+	// the Maps created from a use its new key comparator
+	assert.Equal(t, []interface{}{4, 3, 2, 1, 0}, a.Clone().Keys())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			a.Swap(b)
+		}()
+		go func() {
+			defer wg.Done()
+			b.Swap(a)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 8, a.Size()+b.Size())
+}
+
+func TestMapFloorCeiling(t *testing.T) {
+	m := New()
+	assert.False(t, m.Floor(1).IsValid())
+	assert.False(t, m.Ceiling(1).IsValid())
+
+	for i := 10; i <= 50; i += 10 {
+		m.Insert(i, i*2)
+	}
+	// exact hits
+	assert.Equal(t, 30, m.Floor(30).Key())
+	assert.Equal(t, 60, m.Floor(30).Value())
+	assert.Equal(t, 30, m.Ceiling(30).Key())
+	assert.Equal(t, 10, m.Floor(10).Key())
+	assert.Equal(t, 50, m.Ceiling(50).Key())
+
+	// between keys
+	assert.Equal(t, 20, m.Floor(25).Key())
+	assert.Equal(t, 30, m.Ceiling(25).Key())
+	assert.Equal(t, 60, m.Ceiling(25).Value())
+
+	// out of range
+	assert.False(t, m.Floor(5).IsValid())
+	assert.Equal(t, 10, m.Ceiling(5).Key())
+	assert.Equal(t, 50, m.Floor(55).Key())
+	assert.False(t, m.Ceiling(55).IsValid())
+}
+
+func TestMapDeleteRange(t *testing.T) {
+	m := New()
+	assert.Equal(t, 0, m.DeleteRange(0, 10))
+
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+	}
+	assert.Equal(t, 0, m.DeleteRange(5, 5))
+	assert.Equal(t, 0, m.DeleteRange(6, 2))
+	assert.Equal(t, 0, m.DeleteRange(20, 30))
+
+	// lo is included and hi is excluded
+	assert.Equal(t, 3, m.DeleteRange(2, 5))
+	assert.Equal(t, []interface{}{0, 1, 5, 6, 7, 8, 9}, m.Keys())
+	assert.Equal(t, 2, m.DeleteRange(4, 7))
+	assert.Equal(t, []interface{}{0, 1, 7, 8, 9}, m.Keys())
+
+	assert.Equal(t, 5, m.DeleteRange(-1, 100))
+	assert.Equal(t, 0, m.Size())
+
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, i)
+	}
+	assert.Equal(t, 500, m.DeleteRange(250, 750))
+	assert.Equal(t, 500, m.Size())
+	assert.False(t, m.Contains(250))
+	assert.True(t, m.Contains(249))
+	assert.True(t, m.Contains(750))
+}
+
+func TestMapPopFirstLast(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	_, _, ok := m.PopFirst()
+	assert.False(t, ok)
+	_, _, ok = m.PopLast()
+	assert.False(t, ok)
+
+	for _, i := range []int{5, 3, 9, 1, 7, 2, 8} {
+		m.Insert(i, i*10)
+	}
+	key, value, ok := m.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, 9, key)
+	assert.Equal(t, 90, value)
+
+	var keys []interface{}
+	for {
+		key, value, ok := m.PopFirst()
+		if !ok {
+			break
+		}
+		assert.Equal(t, key.(int)*10, value)
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 5, 7, 8}, keys)
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestMapTraversalSnapshot(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-done
+		for i := 100; i < 1000; i++ {
+			m.Insert(i, i)
+		}
+	}()
+
+	var keys []interface{}
+	m.TraversalSnapshot(func(key, value interface{}) bool {
+		if key == 0 {
+			// the writer runs while the visitor is still visiting, it would deadlock if the lock was held
+			close(done)
+			wg.Wait()
+			// the visitor may even modify the Map
+			m.Erase(50)
+		}
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, 100, len(keys))
+	assert.Equal(t, 50, keys[50])
+	assert.Equal(t, 999, m.Size())
+
+	n := 0
+	m.TraversalSnapshot(func(key, value interface{}) bool {
+		n++
+		return n < 3
+	})
+	assert.Equal(t, 3, n)
+}
+
+func TestMapReplaceValue(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	assert.False(t, m.ReplaceValue(1, "a"))
+	assert.False(t, m.Contains(1))
+	assert.Equal(t, 0, m.Size())
+
+	m.Insert(1, "a")
+	assert.True(t, m.ReplaceValue(1, "b"))
+	assert.Equal(t, "b", m.Get(1))
+	assert.Equal(t, 1, m.Size())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.ReplaceValue(1, i)
+				m.ReplaceValue(2, i)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.False(t, m.Contains(2))
+	assert.Equal(t, 1, m.Size())
+}
+
+func TestMapIteratorCloneEqual(t *testing.T) {
+	m := New()
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+	iter := m.Find(2)
+	marker := iter.Clone().(*MapIterator)
+	assert.True(t, iter.Equal(marker))
+
+	iter.Next()
+	iter.Next()
+	assert.Equal(t, 4, iter.Key())
+	assert.Equal(t, 2, marker.Key())
+	assert.False(t, iter.Equal(marker))
+	assert.True(t, marker.Equal(m.Find(2)))
+
+	// invalid iterators are equal to each other
+	iter.Next()
+	assert.False(t, iter.IsValid())
+	assert.True(t, iter.Equal(m.Find(100)))
+	assert.True(t, iter.Clone().Equal(iter))
+	assert.False(t, iter.Equal(m.RBegin()))
+}
+
+func TestMapIteratorCopySamePosition(t *testing.T) {
+	m := New()
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+	iter := m.Find(2)
+	marker := iter.Copy()
+	assert.True(t, iter.SamePosition(marker))
+
+	iter.Next()
+	assert.Equal(t, 3, iter.Key())
+	assert.Equal(t, 2, marker.Key())
+	assert.False(t, iter.SamePosition(marker))
+	assert.True(t, marker.SamePosition(m.Find(2)))
+	assert.False(t, marker.SamePosition(nil))
+
+	iter.Next()
+	iter.Next()
+	assert.True(t, iter.SamePosition(m.Find(100)))
+	assert.True(t, iter.Copy().SamePosition(iter))
+}
+
+func TestMapMinMax(t *testing.T) {
+	m := New()
+	_, _, ok := m.Min()
+	assert.False(t, ok)
+	_, _, ok = m.Max()
+	assert.False(t, ok)
+
+	m.Insert(5, "five")
+	key, value, ok := m.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 5, key)
+	assert.Equal(t, "five", value)
+	key, value, ok = m.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 5, key)
+	assert.Equal(t, "five", value)
+
+	m.Insert(9, "nine")
+	m.Insert(1, "one")
+	key, value, _ = m.Min()
+	assert.Equal(t, m.Begin().Key(), key)
+	assert.Equal(t, m.Begin().Value(), value)
+	key, value, _ = m.Max()
+	assert.Equal(t, m.Last().Key(), key)
+	assert.Equal(t, "nine", value)
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestMapVerify(t *testing.T) {
+	m := New()
+	assert.Nil(t, m.Verify())
+	for i := 0; i < 1000; i++ {
+		m.Insert(i*7%1000, i)
+	}
+	for i := 0; i < 1000; i += 3 {
+		m.Erase(i)
+	}
+	assert.Nil(t, m.Verify())
+}
+
+func TestMapUpdate(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	incr := func(old interface{}, exists bool) interface{} {
+		if !exists {
+			return 1
+		}
+		return old.(int) + 1
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Update("counter", incr)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5000, m.Get("counter"))
+	assert.Equal(t, 1, m.Size())
+
+	for _, w := range []string{"a", "b", "a", "c", "a", "b"} {
+		m.Update(w, incr)
+	}
+	assert.Equal(t, 3, m.Get("a"))
+	assert.Equal(t, 2, m.Get("b"))
+	assert.Equal(t, 1, m.Get("c"))
+}
+
+func TestMapCompareAndSwap(t *testing.T) {
+	m := New()
+	assert.False(t, m.CompareAndSwap("a", nil, 1, nil))
+	assert.False(t, m.Contains("a"))
+
+	m.Insert("a", []int{1, 2})
+	assert.False(t, m.CompareAndSwap("a", []int{1}, []int{3}, nil))
+	assert.True(t, m.CompareAndSwap("a", []int{1, 2}, []int{3}, nil))
+	assert.Equal(t, []int{3}, m.Get("a"))
+
+	m.Insert("b", 10)
+	sameParity := func(a, b interface{}) bool {
+		return a.(int)%2 == b.(int)%2
+	}
+	assert.True(t, m.CompareAndSwap("b", 2, 11, sameParity))
+	assert.False(t, m.CompareAndSwap("b", 2, 12, sameParity))
+	assert.Equal(t, 11, m.Get("b"))
+}
+
+func TestMapCompareAndSwapConcurrent(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	m.Insert("counter", 0)
+
+	// every goroutine increments the counter with a CAS loop and records the values it swapped from
+	goroutines, n := 20, 200
+	swapped := make(chan int, goroutines*n)
+	wg := sync.WaitGroup{}
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				for {
+					old := m.Get("counter").(int)
+					if m.CompareAndSwap("counter", old, old+1, nil) {
+						swapped <- old
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(swapped)
+
+	// each transition old -> old+1 happened exactly once
+	seen := make(map[int]bool)
+	for old := range swapped {
+		assert.False(t, seen[old], "value %v swapped twice", old)
+		seen[old] = true
+	}
+	assert.Equal(t, goroutines*n, len(seen))
+	for i := 0; i < goroutines*n; i++ {
+		assert.True(t, seen[i])
+	}
+	assert.Equal(t, goroutines*n, m.Get("counter"))
+}
+
+func TestMapEraseIf(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+	assert.Equal(t, 50, m.EraseIf(func(key, value interface{}) bool {
+		return value.(int)%2 == 0
+	}))
+	assert.Equal(t, 50, m.Size())
+	assert.False(t, m.Contains(0))
+	assert.True(t, m.Contains(99))
+	assert.Nil(t, m.Verify())
+}
+
+func TestMapWeightedSample(t *testing.T) {
+	m := New()
+	r := rand.New(rand.NewSource(1))
+	weightOf := func(value interface{}) float64 {
+		return value.(float64)
+	}
+	_, ok := m.WeightedSample(r, weightOf)
+	assert.False(t, ok)
+
+	m.Insert("zero", 0.0)
+	_, ok = m.WeightedSample(r, weightOf)
+	assert.False(t, ok)
+
+	weights := map[string]float64{"a": 1, "b": 2, "c": 3, "d": 4}
+	for k, w := range weights {
+		m.Insert(k, w)
+	}
+	counts := make(map[interface{}]int)
+	n := 100000
+	for i := 0; i < n; i++ {
+		key, ok := m.WeightedSample(r, weightOf)
+		assert.True(t, ok)
+		counts[key]++
+	}
+	assert.Equal(t, 0, counts["zero"])
+	for k, w := range weights {
+		freq := float64(counts[k]) / float64(n)
+		assert.True(t, math.Abs(freq-w/10) < 0.01, "key %v: frequency %v, expected %v", k, freq, w/10)
+	}
+}
+
+func TestMapCountRange(t *testing.T) {
+	m := New()
+	for i := 0; i < 100; i += 3 {
+		m.Insert(i, i)
+	}
+	count := func(lo, hi interface{}) int {
+		n := 0
+		m.Range(lo, hi, func(key, value interface{}) bool {
+			n++
+			return true
+		})
+		return n
+	}
+	bounds := []interface{}{nil, Unbounded, -10, 0, 1, 3, 50, 51, 99, 100, 200}
+	for _, lo := range bounds {
+		for _, hi := range bounds {
+			assert.Equal(t, count(lo, hi), m.CountRange(lo, hi), "lo=%v hi=%v", lo, hi)
+		}
+	}
+	assert.Equal(t, m.Size(), m.CountRange(nil, nil))
+	assert.Equal(t, m.Size(), m.CountRange(Unbounded, Unbounded))
+	assert.Equal(t, 17, m.CountRange(nil, 50))
+	assert.Equal(t, 17, m.CountRange(50, nil))
+	assert.Equal(t, 0, m.CountRange(50, 10))
+	assert.Equal(t, 0, New().CountRange(nil, nil))
+
+	// nil bounds are open even if the key comparator allows nil keys
+	m = New(WithKeyComparator(comparator.NullsFirst(comparator.IntComparator)))
+	m.Insert(nil, "nil")
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+	assert.Equal(t, 6, m.CountRange(nil, nil))
+	assert.Equal(t, 1, m.CountRange(nil, 0))
+	assert.Equal(t, 5, m.CountRange(0, nil))
+	assert.Equal(t, 3, m.CountRange(Unbounded, 2))
+}
+
+func TestMapPutAll(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	m.Insert("b", 0)
+	m.PutAll(map[interface{}]interface{}{"c": 3, "a": 1, "b": 2})
+	assert.Equal(t, []interface{}{"a", "b", "c"}, m.Keys())
+	assert.Equal(t, []interface{}{1, 2, 3}, m.Values())
+
+	m.PutAllReflect(map[string]int{"e": 5, "d": 4})
+	assert.Equal(t, []interface{}{"a", "b", "c", "d", "e"}, m.Keys())
+	assert.Equal(t, 5, m.Get("e"))
+
+	ints := New()
+	ints.PutAllReflect(map[int]string{3: "c", 1: "a", 2: "b"})
+	assert.Equal(t, []interface{}{1, 2, 3}, ints.Keys())
+	assert.Equal(t, []interface{}{"a", "b", "c"}, ints.Values())
+
+	ints.PutAllReflect(map[int]string(nil))
+	assert.Equal(t, 3, ints.Size())
+	assert.Panics(t, func() { ints.PutAllReflect([]int{1}) })
+}
+
+func TestMapFilterMapValues(t *testing.T) {
+	m := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+
+	even := m.Filter(func(key, value interface{}) bool {
+		return key.(int)%2 == 0
+	})
+	assert.Equal(t, []interface{}{8, 6, 4, 2, 0}, even.Keys())
+	assert.Equal(t, []interface{}{80, 60, 40, 20, 0}, even.Values())
+	assert.Nil(t, even.Verify())
+	// the comparator is preserved
+	even.Insert(5, 50)
+	assert.Equal(t, []interface{}{8, 6, 5, 4, 2, 0}, even.Keys())
+
+	strs := m.MapValues(func(key, value interface{}) interface{} {
+		return fmt.Sprintf("%v:%v", key, value)
+	})
+	assert.Equal(t, m.Keys(), strs.Keys())
+	assert.Equal(t, "3:30", strs.Get(3))
+	strs.Insert(100, "x")
+	assert.Equal(t, 100, strs.Keys()[0])
+
+	// the source is untouched
+	assert.Equal(t, 10, m.Size())
+	assert.Equal(t, 30, m.Get(3))
+	assert.False(t, m.Contains(100))
+
+	assert.Equal(t, 0, m.Filter(func(key, value interface{}) bool { return false }).Size())
+}
+
+func TestMapPartition(t *testing.T) {
+	m := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+
+	matching, rest := m.Partition(func(key, value interface{}) bool {
+		return value.(int) >= 50
+	})
+	assert.Equal(t, []interface{}{9, 8, 7, 6, 5}, matching.Keys())
+	assert.Equal(t, []interface{}{90, 80, 70, 60, 50}, matching.Values())
+	assert.Equal(t, []interface{}{4, 3, 2, 1, 0}, rest.Keys())
+	assert.Nil(t, matching.Verify())
+	assert.Nil(t, rest.Verify())
+
+	// disjoint, and together they reconstruct the source
+	all := New()
+	for _, key := range matching.Keys() {
+		assert.False(t, rest.Contains(key))
+		all.Insert(key, nil)
+	}
+	for _, key := range rest.Keys() {
+		all.Insert(key, nil)
+	}
+	assert.Equal(t, m.Size(), all.Size())
+	for _, key := range m.Keys() {
+		assert.True(t, all.Contains(key))
+	}
+
+	// the comparator is preserved and the source is untouched
+	rest.Insert(100, 1000)
+	assert.Equal(t, 100, rest.Keys()[0])
+	assert.Equal(t, 10, m.Size())
+	assert.False(t, m.Contains(100))
+
+	matching, rest = m.Partition(func(key, value interface{}) bool { return true })
+	assert.Equal(t, m.Keys(), matching.Keys())
+	assert.True(t, rest.IsEmpty())
+
+	matching, rest = m.Partition(func(key, value interface{}) bool { return false })
+	assert.True(t, matching.IsEmpty())
+	assert.Equal(t, m.Values(), rest.Values())
+
+	matching, rest = New().Partition(func(key, value interface{}) bool { return true })
+	assert.True(t, matching.IsEmpty())
+	assert.True(t, rest.IsEmpty())
+}
+
+func TestMapInvalidIterator(t *testing.T) {
+	m := New()
+	iters := []*MapIterator{m.Find(1), m.LowerBound(1), m.UpperBound(1), m.Begin(), m.First(), m.Last()}
+	for _, iter := range iters {
+		assert.False(t, iter.IsValid())
+		assert.Nil(t, iter.Key())
+		assert.Nil(t, iter.Value())
+		assert.False(t, iter.Next().IsValid())
+		assert.False(t, iter.Prev().IsValid())
+		assert.True(t, iter.Equal(iter.Clone()))
+	}
+	riter := m.RBegin()
+	assert.False(t, riter.IsValid())
+	assert.Nil(t, riter.Key())
+	assert.Nil(t, riter.Value())
+	assert.False(t, riter.Next().IsValid())
+
+	m.Insert(1, 1)
+	iter := m.Find(1)
+	iter.Next()
+	assert.False(t, iter.IsValid())
+	assert.Nil(t, iter.Key())
+	assert.Nil(t, iter.Value())
+}
+
+func TestMapContainsNilValue(t *testing.T) {
+	m := New()
+	m.Insert("a", nil)
+	assert.True(t, m.Contains("a"))
+	assert.Nil(t, m.Get("a"))
+	assert.False(t, m.Contains("b"))
+
+	mm := NewMultiMap()
+	mm.Insert("a", nil)
+	assert.True(t, mm.Contains("a"))
+}
+
+func TestMapRenameKey(t *testing.T) {
+	m := New(WithGoroutineSafe())
+	m.Insert("a", 1)
+	m.Insert("b", 2)
+
+	assert.True(t, m.RenameKey("a", "c"))
+	assert.False(t, m.Contains("a"))
+	assert.Equal(t, 1, m.Get("c"))
+	assert.Equal(t, []interface{}{"b", "c"}, m.Keys())
+
+	assert.False(t, m.RenameKey("x", "y"))
+	assert.False(t, m.Contains("y"))
+	assert.Equal(t, 2, m.Size())
+
+	// the value of the existing newKey is overwritten
+	assert.True(t, m.RenameKey("b", "c"))
+	assert.Equal(t, []interface{}{"c"}, m.Keys())
+	assert.Equal(t, 2, m.Get("c"))
+
+	assert.True(t, m.RenameKey("c", "c"))
+	assert.Equal(t, 2, m.Get("c"))
+	assert.Nil(t, m.Verify())
+}
+
+func TestMapRangeValues(t *testing.T) {
+	m := New()
+	assert.Equal(t, []interface{}{}, m.RangeValues(Unbounded, Unbounded))
+	for i := 0; i < 10; i++ {
+		m.Insert(i*2, fmt.Sprintf("v%d", i*2))
+	}
+	assert.Equal(t, []interface{}{"v4", "v6", "v8"}, m.RangeValues(3, 10))
+	assert.Equal(t, []interface{}{"v4", "v6", "v8"}, m.RangeValues(4, 9))
+	assert.Equal(t, []interface{}{}, m.RangeValues(5, 6))
+	assert.Equal(t, []interface{}{}, m.RangeValues(10, 3))
+	assert.Equal(t, []interface{}{}, m.RangeValues(100, Unbounded))
+	assert.Equal(t, m.Values(), m.RangeValues(Unbounded, Unbounded))
+	assert.Equal(t, m.Values(), m.RangeValues(-1, 100))
+	assert.Equal(t, []interface{}{"v0", "v2"}, m.RangeValues(Unbounded, 3))
+	assert.Equal(t, []interface{}{"v16", "v18"}, m.RangeValues(16, Unbounded))
+	assert.Equal(t, m.Values(), m.RangeValues(nil, nil))
+	assert.Equal(t, []interface{}{"v0", "v2"}, m.RangeValues(nil, 3))
+	assert.Equal(t, []interface{}{"v16", "v18"}, m.RangeValues(16, nil))
+	assert.Equal(t, []interface{}{}, m.RangeValues(100, nil))
+
+	// nil bounds are open even if the key comparator allows nil keys
+	m = New(WithKeyComparator(comparator.NullsFirst(comparator.IntComparator)))
+	m.Insert(nil, "nil")
+	m.Insert(1, "v1")
+	m.Insert(2, "v2")
+	assert.Equal(t, []interface{}{"nil", "v1"}, m.RangeValues(nil, 2))
+	assert.Equal(t, []interface{}{"nil", "v1", "v2"}, m.RangeValues(nil, nil))
+	assert.Equal(t, []interface{}{"v1", "v2"}, m.RangeValues(1, Unbounded))
+}
+
+func TestMapDiff(t *testing.T) {
+	before := New()
+	after := New()
+	for i := 0; i < 10; i++ {
+		before.Insert(i, i)
+		after.Insert(i, i)
+	}
+	added, removed, changed := before.Diff(after, nil)
+	assert.Equal(t, 0, added.Size())
+	assert.Equal(t, 0, removed.Size())
+	assert.Equal(t, 0, changed.Size())
+
+	after.Erase(0)
+	after.Erase(5)
+	after.Insert(3, 30)
+	after.Insert(9, 90)
+	after.Insert(10, 10)
+	after.Insert(-1, -1)
+
+	added, removed, changed = before.Diff(after, nil)
+	assert.Equal(t, []interface{}{-1, 10}, added.Keys())
+	assert.Equal(t, []interface{}{-1, 10}, added.Values())
+	assert.Equal(t, []interface{}{0, 5}, removed.Keys())
+	assert.Equal(t, []interface{}{0, 5}, removed.Values())
+	assert.Equal(t, []interface{}{3, 9}, changed.Keys())
+	assert.Equal(t, []interface{}{30, 90}, changed.Values())
+	assert.Nil(t, changed.Verify())
+
+	// the values are compared by valueEqual
+	_, _, changed = before.Diff(after, func(a, b interface{}) bool { return true })
+	assert.Equal(t, 0, changed.Size())
+
+	// the reverse diff swaps added and removed
+	added, removed, _ = after.Diff(before, nil)
+	assert.Equal(t, []interface{}{0, 5}, added.Keys())
+	assert.Equal(t, []interface{}{-1, 10}, removed.Keys())
+
+	added, removed, changed = before.Diff(before, nil)
+	assert.Equal(t, 0, added.Size()+removed.Size()+changed.Size())
+
+	// valueEqual is called when comparing a Map with itself
+	_, _, changed = before.Diff(before, func(a, b interface{}) bool { return a != 3 })
+	assert.Equal(t, []interface{}{3}, changed.Keys())
+}
+
+func TestMapDiffDifferentComparators(t *testing.T) {
+	before := New()
+	after := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 0; i < 10; i++ {
+		before.Insert(i, i)
+		after.Insert(i, i)
+	}
+	added, removed, changed := before.Diff(after, nil)
+	assert.Equal(t, 0, added.Size()+removed.Size()+changed.Size())
+
+	after.Erase(0)
+	after.Erase(5)
+	after.Insert(3, 30)
+	after.Insert(9, 90)
+	after.Insert(10, 10)
+	after.Insert(-1, -1)
+
+	added, removed, changed = before.Diff(after, nil)
+	assert.Equal(t, []interface{}{-1, 10}, added.Keys())
+	assert.Equal(t, []interface{}{0, 5}, removed.Keys())
+	assert.Equal(t, []interface{}{3, 9}, changed.Keys())
+	assert.Equal(t, []interface{}{30, 90}, changed.Values())
+	assert.Nil(t, added.Verify())
+	assert.Nil(t, changed.Verify())
+
+	added, removed, _ = after.Diff(before, nil)
+	assert.Equal(t, []interface{}{5, 0}, added.Keys())
+	assert.Equal(t, []interface{}{10, -1}, removed.Keys())
+}
+
+func TestMapThreadSafeIterators(t *testing.T) {
+	m := New(WithGoroutineSafe(), WithThreadSafeIterators())
+	for i := 0; i < 5; i++ {
+		m.Insert(i, i)
+	}
+
+	iter := m.Begin()
+	done := make(chan struct{})
+	go func() {
+		m.Insert(5, 5)
+		close(done)
+	}()
+
+	var keys []interface{}
+	for ; iter.IsValid(); iter.Next() {
+		select {
+		case <-done:
+			t.Fatal("the writer should be blocked during the iteration")
+		case <-time.After(5 * time.Millisecond):
+		}
+		keys = append(keys, iter.Key())
+	}
+	// the writer proceeds after the iteration ends
+	<-done
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, keys)
+	assert.Equal(t, 6, m.Size())
+
+	// closing an iterator before the end releases the lock
+	iter = m.Last()
+	iter.Prev()
+	iter.Close()
+	iter.Close()
+	m.Insert(6, 6)
+	assert.Equal(t, 7, m.Size())
+
+	// the iterators of an empty Map don't hold the lock
+	empty := New(WithGoroutineSafe(), WithThreadSafeIterators())
+	assert.False(t, empty.First().IsValid())
+	empty.Insert(1, 1)
+	assert.Equal(t, 1, empty.Size())
+}
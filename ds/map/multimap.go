@@ -18,14 +18,13 @@ type MultiMap struct {
 func NewMultiMap(opts ...Option) *MultiMap {
 	option := Options{
 		keyCmp: defaultKeyComparator,
-		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
 	return &MultiMap{tree: rbtree.New(rbtree.WithKeyComparator(option.keyCmp)),
 		keyCmp: option.keyCmp,
-		locker: option.locker,
+		locker: option.newLocker(),
 	}
 }
 
@@ -49,6 +48,30 @@ func (mm *MultiMap) Get(key interface{}) interface{} {
 	return nil
 }
 
+//GetAll returns the values of all nodes with key in insertion order, or nil if not found
+func (mm *MultiMap) GetAll(key interface{}) []interface{} {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	var values []interface{}
+	for node := mm.tree.FindNode(key); node != nil && mm.keyCmp(node.Key(), key) == 0; node = node.Next() {
+		values = append(values, node.Value())
+	}
+	return values
+}
+
+//Count returns the number of elements with key in the MultiMap
+func (mm *MultiMap) Count(key interface{}) int {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	upper := mm.tree.FindUpperBoundNode(key)
+	if upper == nil {
+		return mm.tree.Size() - mm.tree.Rank(key)
+	}
+	return mm.tree.Rank(upper.Key()) - mm.tree.Rank(key)
+}
+
 //Erase erases key in the Map
 func (mm *MultiMap) Erase(key interface{}) {
 	mm.locker.Lock()
@@ -68,7 +91,7 @@ func (mm *MultiMap) Find(key interface{}) *MapIterator {
 	defer mm.locker.RUnlock()
 
 	node := mm.tree.FindNode(key)
-	return &MapIterator{node: node}
+	return newMapIterator(node)
 }
 
 //LowerBound returns the first iterator that equal or greater than key in the Map
@@ -77,7 +100,25 @@ func (mm *MultiMap) LowerBound(key interface{}) *MapIterator {
 	defer mm.locker.RUnlock()
 
 	node := mm.tree.FindLowerBoundNode(key)
-	return &MapIterator{node: node}
+	return newMapIterator(node)
+}
+
+//UpperBound returns the first iterator that greater than key in the MultiMap
+func (mm *MultiMap) UpperBound(key interface{}) *MapIterator {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	node := mm.tree.FindUpperBoundNode(key)
+	return newMapIterator(node)
+}
+
+//EqualRange returns the LowerBound and UpperBound iterators of key in the MultiMap, they make up a half-open range [lower, upper)
+//which contains all elements with key in insertion order
+func (mm *MultiMap) EqualRange(key interface{}) (*MapIterator, *MapIterator) {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	return newMapIterator(mm.tree.FindLowerBoundNode(key)), newMapIterator(mm.tree.FindUpperBoundNode(key))
 }
 
 //Begin returns the iterator with the minimum key in the Map, return nil if empty.
@@ -85,7 +126,7 @@ func (mm *MultiMap) Begin() *MapIterator {
 	mm.locker.RLock()
 	defer mm.locker.RUnlock()
 
-	return &MapIterator{node: mm.tree.First()}
+	return newMapIterator(mm.tree.First())
 }
 
 //First returns the iterator with the minimum key in the Map, return nil if empty.
@@ -93,7 +134,7 @@ func (mm *MultiMap) First() *MapIterator {
 	mm.locker.RLock()
 	defer mm.locker.RUnlock()
 
-	return &MapIterator{node: mm.tree.First()}
+	return newMapIterator(mm.tree.First())
 }
 
 //Last returns the iterator with the maximum key in the Map, return nil if empty.
@@ -101,7 +142,7 @@ func (mm *MultiMap) Last() *MapIterator {
 	mm.locker.RLock()
 	defer mm.locker.RUnlock()
 
-	return &MapIterator{node: mm.tree.Last()}
+	return newMapIterator(mm.tree.Last())
 }
 
 //Clear clears the Map
@@ -117,10 +158,7 @@ func (mm *MultiMap) Contains(value interface{}) bool {
 	mm.locker.RLock()
 	defer mm.locker.RUnlock()
 
-	if mm.tree.Find(value) != nil {
-		return true
-	}
-	return false
+	return mm.tree.FindNode(value) != nil
 }
 
 // Size returns the size of Map
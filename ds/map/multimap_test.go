@@ -77,3 +77,41 @@ func TestMultiMap_Traversal(t *testing.T) {
 		return true
 	})
 }
+
+func TestMultiMapGroup(t *testing.T) {
+	m := NewMultiMap()
+	assert.Nil(t, m.GetAll(1))
+	assert.Equal(t, 0, m.Count(1))
+
+	for i := 0; i < 100; i++ {
+		m.Insert(i%3, i)
+	}
+	m.Insert(5, "x")
+	assert.Equal(t, 34, m.Count(0))
+	assert.Equal(t, 33, m.Count(2))
+	assert.Equal(t, 1, m.Count(5))
+
+	values := m.GetAll(1)
+	assert.Equal(t, 33, len(values))
+	for i, v := range values {
+		assert.Equal(t, i*3+1, v)
+	}
+	assert.Equal(t, 1, m.Get(1))
+
+	lower, upper := m.EqualRange(2)
+	expected := 2
+	for iter := lower; !iter.Equal(upper); iter.Next() {
+		assert.Equal(t, 2, iter.Key())
+		assert.Equal(t, expected, iter.Value())
+		expected += 3
+	}
+	assert.Equal(t, 101, expected)
+	assert.Equal(t, 5, upper.Key())
+	lower, upper = m.EqualRange(3)
+	assert.True(t, lower.Equal(upper))
+	assert.False(t, m.UpperBound(5).IsValid())
+
+	m.Erase(1)
+	assert.Nil(t, m.GetAll(1))
+	assert.Equal(t, 68, m.Size())
+}
@@ -0,0 +1,103 @@
+//go:build ignore
+// +build ignore
+
+// gen.go generates typed_gen.go, run it by go generate after changing the list of wrappers or the template.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"text/template"
+)
+
+type wrapper struct {
+	Name       string
+	KeyType    string
+	ValueType  string
+	Comparator string
+	ZeroValue  string
+}
+
+var wrappers = []wrapper{
+	{Name: "StringIntMap", KeyType: "string", ValueType: "int", Comparator: "StringComparator", ZeroValue: "0"},
+	{Name: "IntStringMap", KeyType: "int", ValueType: "string", Comparator: "IntComparator", ZeroValue: `""`},
+	{Name: "StringStringMap", KeyType: "string", ValueType: "string", Comparator: "StringComparator", ZeroValue: `""`},
+}
+
+var tmpl = template.Must(template.New("typed").Parse(`// Code generated by gen.go; DO NOT EDIT.
+
+package typed
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+)
+{{range .}}
+// {{.Name}} is a treemap.Map with {{.KeyType}} keys and {{.ValueType}} values, it orders the keys by comparator.{{.Comparator}} by default.
+// The methods of the embedded Map which are not overridden take and return interface{} values.
+// The keys and values are still stored as interface{}, so it saves the type assertions but not the allocations.
+type {{.Name}} struct {
+	*treemap.Map
+}
+
+// New{{.Name}} creates a new {{.Name}}, opts are applied after the default key comparator
+func New{{.Name}}(opts ...treemap.Option) *{{.Name}} {
+	opts = append([]treemap.Option{treemap.WithKeyComparator(comparator.{{.Comparator}})}, opts...)
+	return &{{.Name}}{Map: treemap.New(opts...)}
+}
+
+// Insert inserts key-value to the map, the value will be replaced if key exists
+func (m *{{.Name}}) Insert(key {{.KeyType}}, value {{.ValueType}}) {
+	m.Map.Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or {{.ZeroValue}} and false if not found
+func (m *{{.Name}}) Get(key {{.KeyType}}) ({{.ValueType}}, bool) {
+	value, ok := m.Map.Lookup(key)
+	if !ok {
+		return {{.ZeroValue}}, false
+	}
+	return value.({{.ValueType}}), true
+}
+
+// Keys returns all the keys in ascending order
+func (m *{{.Name}}) Keys() []{{.KeyType}} {
+	keys := make([]{{.KeyType}}, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		keys = append(keys, key.({{.KeyType}}))
+	})
+	return keys
+}
+
+// Values returns all the values in ascending order of keys
+func (m *{{.Name}}) Values() []{{.ValueType}} {
+	values := make([]{{.ValueType}}, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		values = append(values, value.({{.ValueType}}))
+	})
+	return values
+}
+
+// ForEach calls fn for every element in ascending order of keys, fn must not modify the map
+func (m *{{.Name}}) ForEach(fn func(key {{.KeyType}}, value {{.ValueType}})) {
+	m.Map.ForEach(func(key, value interface{}) {
+		fn(key.({{.KeyType}}), value.({{.ValueType}}))
+	})
+}
+{{end}}`))
+
+func main() {
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, wrappers); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("typed_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,4 @@
+package typed
+
+// The wrappers are generated from the list in gen.go, add a new one there instead of writing it by hand.
+//go:generate go run gen.go
@@ -0,0 +1,164 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package typed
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+)
+
+// StringIntMap is a treemap.Map with string keys and int values, it orders the keys by comparator.StringComparator by default.
+// The methods of the embedded Map which are not overridden take and return interface{} values.
+// The keys and values are still stored as interface{}, so it saves the type assertions but not the allocations.
+type StringIntMap struct {
+	*treemap.Map
+}
+
+// NewStringIntMap creates a new StringIntMap, opts are applied after the default key comparator
+func NewStringIntMap(opts ...treemap.Option) *StringIntMap {
+	opts = append([]treemap.Option{treemap.WithKeyComparator(comparator.StringComparator)}, opts...)
+	return &StringIntMap{Map: treemap.New(opts...)}
+}
+
+// Insert inserts key-value to the map, the value will be replaced if key exists
+func (m *StringIntMap) Insert(key string, value int) {
+	m.Map.Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or 0 and false if not found
+func (m *StringIntMap) Get(key string) (int, bool) {
+	value, ok := m.Map.Lookup(key)
+	if !ok {
+		return 0, false
+	}
+	return value.(int), true
+}
+
+// Keys returns all the keys in ascending order
+func (m *StringIntMap) Keys() []string {
+	keys := make([]string, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		keys = append(keys, key.(string))
+	})
+	return keys
+}
+
+// Values returns all the values in ascending order of keys
+func (m *StringIntMap) Values() []int {
+	values := make([]int, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		values = append(values, value.(int))
+	})
+	return values
+}
+
+// ForEach calls fn for every element in ascending order of keys, fn must not modify the map
+func (m *StringIntMap) ForEach(fn func(key string, value int)) {
+	m.Map.ForEach(func(key, value interface{}) {
+		fn(key.(string), value.(int))
+	})
+}
+
+// IntStringMap is a treemap.Map with int keys and string values, it orders the keys by comparator.IntComparator by default.
+// The methods of the embedded Map which are not overridden take and return interface{} values.
+// The keys and values are still stored as interface{}, so it saves the type assertions but not the allocations.
+type IntStringMap struct {
+	*treemap.Map
+}
+
+// NewIntStringMap creates a new IntStringMap, opts are applied after the default key comparator
+func NewIntStringMap(opts ...treemap.Option) *IntStringMap {
+	opts = append([]treemap.Option{treemap.WithKeyComparator(comparator.IntComparator)}, opts...)
+	return &IntStringMap{Map: treemap.New(opts...)}
+}
+
+// Insert inserts key-value to the map, the value will be replaced if key exists
+func (m *IntStringMap) Insert(key int, value string) {
+	m.Map.Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or "" and false if not found
+func (m *IntStringMap) Get(key int) (string, bool) {
+	value, ok := m.Map.Lookup(key)
+	if !ok {
+		return "", false
+	}
+	return value.(string), true
+}
+
+// Keys returns all the keys in ascending order
+func (m *IntStringMap) Keys() []int {
+	keys := make([]int, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		keys = append(keys, key.(int))
+	})
+	return keys
+}
+
+// Values returns all the values in ascending order of keys
+func (m *IntStringMap) Values() []string {
+	values := make([]string, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		values = append(values, value.(string))
+	})
+	return values
+}
+
+// ForEach calls fn for every element in ascending order of keys, fn must not modify the map
+func (m *IntStringMap) ForEach(fn func(key int, value string)) {
+	m.Map.ForEach(func(key, value interface{}) {
+		fn(key.(int), value.(string))
+	})
+}
+
+// StringStringMap is a treemap.Map with string keys and string values, it orders the keys by comparator.StringComparator by default.
+// The methods of the embedded Map which are not overridden take and return interface{} values.
+// The keys and values are still stored as interface{}, so it saves the type assertions but not the allocations.
+type StringStringMap struct {
+	*treemap.Map
+}
+
+// NewStringStringMap creates a new StringStringMap, opts are applied after the default key comparator
+func NewStringStringMap(opts ...treemap.Option) *StringStringMap {
+	opts = append([]treemap.Option{treemap.WithKeyComparator(comparator.StringComparator)}, opts...)
+	return &StringStringMap{Map: treemap.New(opts...)}
+}
+
+// Insert inserts key-value to the map, the value will be replaced if key exists
+func (m *StringStringMap) Insert(key string, value string) {
+	m.Map.Insert(key, value)
+}
+
+// Get returns the value by key and true if found, or "" and false if not found
+func (m *StringStringMap) Get(key string) (string, bool) {
+	value, ok := m.Map.Lookup(key)
+	if !ok {
+		return "", false
+	}
+	return value.(string), true
+}
+
+// Keys returns all the keys in ascending order
+func (m *StringStringMap) Keys() []string {
+	keys := make([]string, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		keys = append(keys, key.(string))
+	})
+	return keys
+}
+
+// Values returns all the values in ascending order of keys
+func (m *StringStringMap) Values() []string {
+	values := make([]string, 0, m.Map.Size())
+	m.Map.ForEach(func(key, value interface{}) {
+		values = append(values, value.(string))
+	})
+	return values
+}
+
+// ForEach calls fn for every element in ascending order of keys, fn must not modify the map
+func (m *StringStringMap) ForEach(fn func(key string, value string)) {
+	m.Map.ForEach(func(key, value interface{}) {
+		fn(key.(string), value.(string))
+	})
+}
@@ -0,0 +1,108 @@
+package typed
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestStringIntMap(t *testing.T) {
+	m := NewStringIntMap(treemap.WithGoroutineSafe())
+	m.Insert("b", 2)
+	m.Insert("a", 1)
+	m.Insert("c", 3)
+	m.Insert("a", 10)
+
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	v, ok = m.Get("x")
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keys())
+	assert.Equal(t, []int{10, 2, 3}, m.Values())
+
+	sum := 0
+	m.ForEach(func(key string, value int) {
+		sum += value
+	})
+	assert.Equal(t, 15, sum)
+
+	// the methods of the embedded Map are available
+	m.Erase("b")
+	assert.False(t, m.Contains("b"))
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestIntStringMap(t *testing.T) {
+	m := NewIntStringMap(treemap.WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	m.Insert(1, "one")
+	m.Insert(3, "three")
+	m.Insert(2, "two")
+
+	v, ok := m.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "three", v)
+	v, ok = m.Get(4)
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+
+	// the comparator option overrides the default one
+	assert.Equal(t, []int{3, 2, 1}, m.Keys())
+	assert.Equal(t, []string{"three", "two", "one"}, m.Values())
+
+	var keys []int
+	m.ForEach(func(key int, value string) {
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []int{3, 2, 1}, keys)
+}
+
+func TestStringStringMap(t *testing.T) {
+	m := NewStringStringMap()
+	assert.Equal(t, []string{}, m.Keys())
+	m.Insert("k2", "v2")
+	m.Insert("k1", "v1")
+
+	v, ok := m.Get("k1")
+	assert.True(t, ok)
+	assert.Equal(t, "v1", v)
+	_, ok = m.Get("k3")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"k1", "k2"}, m.Keys())
+	assert.Equal(t, []string{"v1", "v2"}, m.Values())
+
+	pairs := make(map[string]string)
+	m.ForEach(func(key, value string) {
+		pairs[key] = value
+	})
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "v2"}, pairs)
+}
+
+func TestStringIntMapGetWhileInsert(t *testing.T) {
+	// run with -race: Get must read the value under the lock of the map
+	m := NewStringIntMap(treemap.WithGoroutineSafe())
+	m.Insert("a", 0)
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			m.Insert("a", i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			_, ok := m.Get("a")
+			assert.True(t, ok)
+		}
+	}()
+	wg.Wait()
+	v, _ := m.Get("a")
+	assert.Equal(t, 999, v)
+}
@@ -0,0 +1,75 @@
+package treemap
+
+// MapView is a read-only view of the elements with key in range [lo, hi) of a Map, it doesn't copy the elements.
+// The view always reflects the current content of the Map, so the elements inserted to or erased from the range
+// after creating the view are visible in it. The iterators of the view are as unsafe as the ones of the Map
+// when the Map is modified concurrently.
+type MapView struct {
+	m  *Map
+	lo interface{}
+	hi interface{}
+}
+
+// SubMap returns a view of the elements with key in range [lo, hi) of the Map
+func (m *Map) SubMap(lo, hi interface{}) *MapView {
+	return &MapView{m: m, lo: lo, hi: hi}
+}
+
+// Begin returns the iterator with the minimum key in the view, it is equal to End() if the view is empty
+func (v *MapView) Begin() *MapIterator {
+	v.m.locker.RLock()
+	defer v.m.locker.RUnlock()
+
+	if v.m.keyCmp(v.lo, v.hi) >= 0 {
+		return newMapIterator(v.m.tree.FindLowerBoundNode(v.hi))
+	}
+	return newMapIterator(v.m.tree.FindLowerBoundNode(v.lo))
+}
+
+// End returns the iterator past the maximum key in the view, it is the first element that equal or greater than hi in the Map
+func (v *MapView) End() *MapIterator {
+	v.m.locker.RLock()
+	defer v.m.locker.RUnlock()
+
+	return newMapIterator(v.m.tree.FindLowerBoundNode(v.hi))
+}
+
+// Size returns the number of elements in the view, it takes O(log n) time
+func (v *MapView) Size() int {
+	v.m.locker.RLock()
+	defer v.m.locker.RUnlock()
+
+	if v.m.keyCmp(v.lo, v.hi) >= 0 {
+		return 0
+	}
+	return v.m.tree.Rank(v.hi) - v.m.tree.Rank(v.lo)
+}
+
+// Contains returns true if key is in range [lo, hi) and in the Map, otherwise returns false
+func (v *MapView) Contains(key interface{}) bool {
+	if !v.inRange(key) {
+		return false
+	}
+	return v.m.Find(key).IsValid()
+}
+
+// Get returns the value by key if key is in range [lo, hi) and in the Map, or nil if not
+func (v *MapView) Get(key interface{}) interface{} {
+	if !v.inRange(key) {
+		return nil
+	}
+	return v.m.Get(key)
+}
+
+func (v *MapView) inRange(key interface{}) bool {
+	return v.m.keyCmp(key, v.lo) >= 0 && v.m.keyCmp(key, v.hi) < 0
+}
+
+// ForEach calls fn for every element in the view in ascending order of keys.
+// Note that the Map is read-locked during the iteration, so fn must not modify the Map.
+func (v *MapView) ForEach(fn func(key, value interface{})) {
+	v.m.Range(v.lo, v.hi, func(key, value interface{}) bool {
+		fn(key, value)
+		return true
+	})
+}
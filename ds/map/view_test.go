@@ -0,0 +1,57 @@
+package treemap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMapView(t *testing.T) {
+	m := New()
+	v := m.SubMap(10, 20)
+	assert.Equal(t, 0, v.Size())
+	assert.True(t, v.Begin().Equal(v.End()))
+
+	for i := 0; i < 30; i += 2 {
+		m.Insert(i, i*10)
+	}
+	var keys []interface{}
+	for iter := v.Begin(); !iter.Equal(v.End()); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	assert.Equal(t, []interface{}{10, 12, 14, 16, 18}, keys)
+	assert.Equal(t, len(keys), v.Size())
+	assert.Equal(t, 20, v.End().Key())
+
+	keys = nil
+	v.ForEach(func(key, value interface{}) {
+		assert.Equal(t, key.(int)*10, value)
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []interface{}{10, 12, 14, 16, 18}, keys)
+
+	assert.True(t, v.Contains(10))
+	assert.False(t, v.Contains(11))
+	assert.False(t, v.Contains(20))
+	assert.False(t, v.Contains(8))
+	assert.Equal(t, 120, v.Get(12))
+	assert.Nil(t, v.Get(22))
+
+	// the view reflects the changes of the Map
+	m.Insert(11, 110)
+	m.Erase(18)
+	m.Insert(20, 0)
+	assert.Equal(t, 5, v.Size())
+	assert.True(t, v.Contains(11))
+
+	// the end of the view is invalid if no key is equal or greater than hi
+	v = m.SubMap(25, 100)
+	assert.Equal(t, 2, v.Size())
+	assert.False(t, v.End().IsValid())
+
+	v = m.SubMap(20, 10)
+	assert.Equal(t, 0, v.Size())
+	assert.True(t, v.Begin().Equal(v.End()))
+	v.ForEach(func(key, value interface{}) {
+		t.Fatalf("unexpected key %v", key)
+	})
+}
@@ -119,11 +119,14 @@ func (q *PriorityQueue) Push(item interface{}) {
 	heap.Push(q.holder, item)
 }
 
-// Pop pops an item from q
+// Pop pops an item from q, returns nil if q is empty
 func (q *PriorityQueue) Pop() interface{} {
 	q.locker.Lock()
 	defer q.locker.Unlock()
 
+	if q.holder.Size() == 0 {
+		return nil
+	}
 	return heap.Pop(q.holder)
 }
 
@@ -142,3 +145,11 @@ func (q *PriorityQueue) Empty() bool {
 
 	return q.holder.Size() == 0
 }
+
+// Size returns the number of items in q
+func (q *PriorityQueue) Size() int {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.Size()
+}
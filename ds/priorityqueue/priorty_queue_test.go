@@ -2,6 +2,9 @@ package priorityqueue
 
 import (
 	. "github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -40,3 +43,39 @@ func TestStringPriorityQueue(t *testing.T) {
 		t.Logf("%v, %v", pq.Top(), pq.Pop())
 	}
 }
+
+func TestPriorityQueueOrder(t *testing.T) {
+	pq := New(WithGoroutineSafe())
+	assert.True(t, pq.Empty())
+	assert.Equal(t, 0, pq.Size())
+	assert.Nil(t, pq.Top())
+	assert.Nil(t, pq.Pop())
+
+	var ref []int
+	for i := 0; i < 1000; i++ {
+		v := rand.Intn(100)
+		pq.Push(v)
+		ref = append(ref, v)
+		if i%3 == 0 {
+			sort.Ints(ref)
+			assert.Equal(t, ref[0], pq.Top())
+			assert.Equal(t, ref[0], pq.Pop())
+			ref = ref[1:]
+		}
+		assert.Equal(t, len(ref), pq.Size())
+	}
+
+	sort.Ints(ref)
+	for _, v := range ref {
+		assert.Equal(t, v, pq.Pop())
+	}
+	assert.True(t, pq.Empty())
+
+	pq = New(WithComparator(Reverse(BuiltinTypeComparator)))
+	for _, v := range rand.Perm(100) {
+		pq.Push(v)
+	}
+	for i := 99; i >= 0; i-- {
+		assert.Equal(t, i, pq.Pop())
+	}
+}
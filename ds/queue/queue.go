@@ -9,8 +9,7 @@ import (
 )
 
 var (
-	defaultLocker    sync.FakeLocker
-	defaultContainer = deque.New()
+	defaultLocker sync.FakeLocker
 )
 
 // Options holds Queue's options
@@ -52,12 +51,14 @@ type Queue struct {
 //New new a queue
 func New(opts ...Option) *Queue {
 	option := Options{
-		locker:    defaultLocker,
-		container: defaultContainer,
+		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
+	if option.container == nil {
+		option.container = deque.New()
+	}
 
 	return &Queue{
 		container: option.container,
@@ -89,28 +90,43 @@ func (q *Queue) Push(value interface{}) {
 	q.container.PushBack(value)
 }
 
-// Front returns the first value in q
+// Front returns the first value in q, or nil if q is empty
 func (q *Queue) Front() interface{} {
 	q.locker.RLock()
 	defer q.locker.RUnlock()
 
+	if q.container.Empty() {
+		return nil
+	}
 	return q.container.Front()
 }
 
-// Back returns the last value in q
+// Back returns the last value in q, or nil if q is empty
 func (q *Queue) Back() interface{} {
 	q.locker.RLock()
 	defer q.locker.RUnlock()
 
+	if q.container.Empty() {
+		return nil
+	}
 	return q.container.Back()
 }
 
-// Pop removes the the first item in q, and returns it's value
+// Pop removes the the first item in q, and returns it's value, or nil if q is empty
 func (q *Queue) Pop() interface{} {
+	value, _ := q.TryPop()
+	return value
+}
+
+// TryPop removes the the first item in q, and returns it's value and true, or nil and false if q is empty
+func (q *Queue) TryPop() (interface{}, bool) {
 	q.locker.Lock()
 	defer q.locker.Unlock()
 
-	return q.container.PopFront()
+	if q.container.Empty() {
+		return nil, false
+	}
+	return q.container.PopFront(), true
 }
 
 // Clear clears all items in q
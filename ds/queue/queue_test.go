@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -58,3 +60,50 @@ func TestQueueWithListContainer(t *testing.T) {
 		t.Fatalf("size error, expect %v, but get %v", 0, q.Size())
 	}
 }
+
+func TestQueueTryPop(t *testing.T) {
+	q := New()
+	if v, ok := q.TryPop(); ok || v != nil {
+		t.Fatalf("expect nil and false, but get %v and %v", v, ok)
+	}
+	if q.Pop() != nil || q.Front() != nil || q.Back() != nil {
+		t.Fatalf("expect nil")
+	}
+	// interleaved push and pop
+	for i := 0; i < 100; i++ {
+		q.Push(2 * i)
+		q.Push(2*i + 1)
+		if v, ok := q.TryPop(); !ok || v != i {
+			t.Fatalf("expect %v and true, but get %v and %v", i, v, ok)
+		}
+	}
+	if q.Size() != 100 {
+		t.Fatalf("expect %v, but get %v", 100, q.Size())
+	}
+	// every Queue has its own container
+	if New().Size() != 0 {
+		t.Fatalf("expect a new queue to be empty")
+	}
+}
+
+func TestQueueGoroutineSafe(t *testing.T) {
+	q := New(WithGoroutineSafe())
+	wg := sync.WaitGroup{}
+	var popped int64
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				q.Push(i)
+				if _, ok := q.TryPop(); ok {
+					atomic.AddInt64(&popped, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if int(popped)+q.Size() != 4000 {
+		t.Fatalf("expect %v, but get %v", 4000, int(popped)+q.Size())
+	}
+}
@@ -0,0 +1,275 @@
+package radixmap
+
+import (
+	"github.com/liyue201/gostl/utils/sync"
+	"sort"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds RadixMap's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets RadixMap goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+type node struct {
+	// label is the part of the key on the edge from the parent to the node
+	label    string
+	children []*node // sorted by the first bytes of their labels
+	value    interface{}
+	leaf     bool
+}
+
+// child returns the index of the child whose label starts with c, and whether it exists
+func (n *node) child(c byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= c
+	})
+	return i, i < len(n.children) && n.children[i].label[0] == c
+}
+
+func (n *node) insertChild(i int, child *node) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+func (n *node) removeChild(i int) {
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// RadixMap is a map with string keys backed by a radix tree, the shared prefixes of keys are stored only once.
+// The keys are iterated in ascending order as with treemap.Map.
+type RadixMap struct {
+	root   *node
+	size   int
+	locker sync.Locker
+}
+
+// New creates a new RadixMap
+func New(opts ...Option) *RadixMap {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &RadixMap{
+		root:   &node{},
+		locker: option.locker,
+	}
+}
+
+// Insert inserts a key-value to the RadixMap, the value will be replaced if key exists
+func (rm *RadixMap) Insert(key string, value interface{}) {
+	rm.locker.Lock()
+	defer rm.locker.Unlock()
+
+	n := rm.root
+	for len(key) > 0 {
+		i, ok := n.child(key[0])
+		if !ok {
+			// copy the label, so that the whole key is not kept alive by the substring
+			n.insertChild(i, &node{label: string([]byte(key)), value: value, leaf: true})
+			rm.size++
+			return
+		}
+		child := n.children[i]
+		l := commonPrefixLen(key, child.label)
+		if l < len(child.label) {
+			// split the edge at the end of the common prefix
+			mid := &node{label: child.label[:l], children: []*node{child}}
+			child.label = child.label[l:]
+			n.children[i] = mid
+			child = mid
+		}
+		n = child
+		key = key[l:]
+	}
+	if !n.leaf {
+		n.leaf = true
+		rm.size++
+	}
+	n.value = value
+}
+
+// Get returns the value by key and true if found, or nil and false if not found
+func (rm *RadixMap) Get(key string) (interface{}, bool) {
+	rm.locker.RLock()
+	defer rm.locker.RUnlock()
+
+	n, rest := rm.find(key)
+	if n == nil || rest != "" || !n.leaf {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Contains returns true if key is in the RadixMap, otherwise returns false
+func (rm *RadixMap) Contains(key string) bool {
+	_, ok := rm.Get(key)
+	return ok
+}
+
+// Erase erases key from the RadixMap and returns true if it was in the RadixMap
+func (rm *RadixMap) Erase(key string) bool {
+	rm.locker.Lock()
+	defer rm.locker.Unlock()
+
+	if !rm.erase(rm.root, key) {
+		return false
+	}
+	rm.size--
+	return true
+}
+
+func (rm *RadixMap) erase(n *node, key string) bool {
+	if key == "" {
+		if !n.leaf {
+			return false
+		}
+		n.leaf = false
+		n.value = nil
+		return true
+	}
+	i, ok := n.child(key[0])
+	if !ok {
+		return false
+	}
+	child := n.children[i]
+	if len(key) < len(child.label) || key[:len(child.label)] != child.label {
+		return false
+	}
+	if !rm.erase(child, key[len(child.label):]) {
+		return false
+	}
+	// keep the tree compressed: remove the useless child, or merge it with its only child
+	if !child.leaf {
+		switch len(child.children) {
+		case 0:
+			n.removeChild(i)
+		case 1:
+			grandChild := child.children[0]
+			grandChild.label = child.label + grandChild.label
+			n.children[i] = grandChild
+		}
+	}
+	return true
+}
+
+// Size returns the number of keys in the RadixMap
+func (rm *RadixMap) Size() int {
+	rm.locker.RLock()
+	defer rm.locker.RUnlock()
+
+	return rm.size
+}
+
+// IsEmpty returns true if the RadixMap is empty, otherwise returns false
+func (rm *RadixMap) IsEmpty() bool {
+	return rm.Size() == 0
+}
+
+// Clear clears the RadixMap
+func (rm *RadixMap) Clear() {
+	rm.locker.Lock()
+	defer rm.locker.Unlock()
+
+	rm.root = &node{}
+	rm.size = 0
+}
+
+// Keys returns all the keys in ascending order
+func (rm *RadixMap) Keys() []string {
+	return rm.WithPrefix("")
+}
+
+// WithPrefix returns all the keys with prefix in ascending order, all the keys are returned if prefix is empty
+func (rm *RadixMap) WithPrefix(prefix string) []string {
+	keys := make([]string, 0)
+	rm.Walk(prefix, func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Walk calls fn for every key with prefix and its value in ascending order of keys until fn returns false.
+// Note that the RadixMap is read-locked during the walking, so fn must not modify the RadixMap.
+func (rm *RadixMap) Walk(prefix string, fn func(key string, value interface{}) bool) {
+	rm.locker.RLock()
+	defer rm.locker.RUnlock()
+
+	n, rest := rm.find(prefix)
+	if n == nil {
+		return
+	}
+	// the prefix may end in the middle of the label of n
+	buf := make([]byte, 0, 64)
+	buf = append(buf, prefix...)
+	buf = append(buf, rest...)
+	walk(n, buf, fn)
+}
+
+// Traversal traversals all the elements in ascending order of keys, it will not stop until to the end or fn returns false
+func (rm *RadixMap) Traversal(fn func(key string, value interface{}) bool) {
+	rm.Walk("", fn)
+}
+
+// find returns the node which is the first one whose path from the root starts with key, and the remaining part of its
+// label which is not matched by key. It returns nil if no key in the RadixMap starts with key.
+func (rm *RadixMap) find(key string) (*node, string) {
+	n := rm.root
+	for len(key) > 0 {
+		i, ok := n.child(key[0])
+		if !ok {
+			return nil, ""
+		}
+		child := n.children[i]
+		l := commonPrefixLen(key, child.label)
+		if l < len(key) && l < len(child.label) {
+			return nil, ""
+		}
+		if l == len(key) {
+			return child, child.label[l:]
+		}
+		n = child
+		key = key[l:]
+	}
+	return n, ""
+}
+
+func walk(n *node, buf []byte, fn func(key string, value interface{}) bool) bool {
+	if n.leaf && !fn(string(buf), n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !walk(child, append(buf, child.label...), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
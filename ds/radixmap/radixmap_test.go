@@ -0,0 +1,181 @@
+package radixmap
+
+import (
+	"fmt"
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRadixMap(t *testing.T) {
+	rm := New()
+	assert.True(t, rm.IsEmpty())
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom", ""} {
+		rm.Insert(key, i)
+	}
+	assert.Equal(t, 9, rm.Size())
+
+	v, ok := rm.Get("rom")
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+	v, ok = rm.Get("")
+	assert.True(t, ok)
+	assert.Equal(t, 8, v)
+	_, ok = rm.Get("ro")
+	assert.False(t, ok)
+	_, ok = rm.Get("romanes")
+	assert.False(t, ok)
+	assert.False(t, rm.Contains("rub"))
+
+	assert.Equal(t, []string{"rom", "romane", "romanus", "romulus"}, rm.WithPrefix("rom"))
+	assert.Equal(t, []string{"romane", "romanus"}, rm.WithPrefix("roma"))
+	assert.Equal(t, []string{"rubicon", "rubicundus"}, rm.WithPrefix("rubic"))
+	assert.Equal(t, []string{}, rm.WithPrefix("rx"))
+	assert.Equal(t, []string{"", "rom", "romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}, rm.Keys())
+
+	rm.Insert("rom", 70)
+	v, _ = rm.Get("rom")
+	assert.Equal(t, 70, v)
+	assert.Equal(t, 9, rm.Size())
+
+	var visited []string
+	rm.Walk("r", func(key string, value interface{}) bool {
+		visited = append(visited, key)
+		return len(visited) < 2
+	})
+	assert.Equal(t, []string{"rom", "romane"}, visited)
+}
+
+func TestRadixMapErase(t *testing.T) {
+	rm := New(WithGoroutineSafe())
+	for _, key := range []string{"test", "team", "toast", "te"} {
+		rm.Insert(key, key)
+	}
+	assert.False(t, rm.Erase("t"))
+	assert.False(t, rm.Erase("tea"))
+	assert.False(t, rm.Erase("teams"))
+
+	assert.True(t, rm.Erase("te"))
+	assert.False(t, rm.Contains("te"))
+	assert.Equal(t, []string{"team", "test"}, rm.WithPrefix("te"))
+
+	assert.True(t, rm.Erase("team"))
+	// "te" and "st" are merged into one edge
+	i, _ := rm.root.children[0].child('e')
+	assert.Equal(t, "est", rm.root.children[0].children[i].label)
+
+	assert.True(t, rm.Erase("test"))
+	assert.True(t, rm.Erase("toast"))
+	assert.True(t, rm.IsEmpty())
+	assert.Equal(t, 0, len(rm.root.children))
+
+	rm.Insert("a", nil)
+	rm.Clear()
+	assert.Equal(t, []string{}, rm.Keys())
+}
+
+func randomKeys(r *rand.Rand, n int) []string {
+	parts := []string{"a", "b", "ab", "ba", "abc", "/", "x"}
+	keys := make([]string, n)
+	for i := range keys {
+		var sb strings.Builder
+		for j := r.Intn(6); j >= 0; j-- {
+			sb.WriteString(parts[r.Intn(len(parts))])
+		}
+		keys[i] = sb.String()
+	}
+	return keys
+}
+
+func TestRadixMapAgainstTreeMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rm := New()
+	tm := treemap.New()
+	keys := randomKeys(r, 2000)
+	for i, key := range keys {
+		if r.Intn(3) == 0 {
+			assert.Equal(t, tm.Contains(key), rm.Erase(key))
+			tm.Erase(key)
+		} else {
+			rm.Insert(key, i)
+			tm.Insert(key, i)
+		}
+	}
+	assert.Equal(t, tm.Size(), rm.Size())
+	var expected []string
+	tm.ForEach(func(key, value interface{}) {
+		expected = append(expected, key.(string))
+		v, ok := rm.Get(key.(string))
+		assert.True(t, ok)
+		assert.Equal(t, value, v)
+	})
+	assert.Equal(t, expected, rm.Keys())
+
+	for _, prefix := range []string{"", "a", "ab", "abca", "b/", "x"} {
+		var withPrefix []string
+		for _, key := range expected {
+			if strings.HasPrefix(key, prefix) {
+				withPrefix = append(withPrefix, key)
+			}
+		}
+		assert.Equal(t, len(withPrefix), len(rm.WithPrefix(prefix)))
+		if len(withPrefix) > 0 {
+			assert.Equal(t, withPrefix, rm.WithPrefix(prefix))
+		}
+	}
+}
+
+func urls(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("https://example.com/api/v1/users/%d/profile/settings/%d", i/10, i%10)
+	}
+	return keys
+}
+
+func benchmarkMemory(b *testing.B, insert func(keys []string) interface{}) {
+	keys := urls(10000)
+	b.ReportAllocs()
+	var ms runtime.MemStats
+	var total uint64
+	for i := 0; i < b.N; i++ {
+		// the keys are rebuilt so that their memory is counted too
+		copied := make([]string, len(keys))
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		before := ms.HeapAlloc
+		for j, key := range keys {
+			copied[j] = string([]byte(key))
+		}
+		m := insert(copied)
+		copied = nil
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		total += ms.HeapAlloc - before
+		runtime.KeepAlive(m)
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "heap-bytes/op")
+}
+
+func BenchmarkRadixMapMemory(b *testing.B) {
+	benchmarkMemory(b, func(keys []string) interface{} {
+		rm := New()
+		for _, key := range keys {
+			rm.Insert(key, nil)
+		}
+		return rm
+	})
+}
+
+func BenchmarkTreeMapMemory(b *testing.B) {
+	benchmarkMemory(b, func(keys []string) interface{} {
+		tm := treemap.New()
+		for _, key := range keys {
+			tm.Insert(key, nil)
+		}
+		return tm
+	})
+}
@@ -14,6 +14,11 @@ func NewIterator(node *Node) *RbTreeIterator {
 	return &RbTreeIterator{node: node}
 }
 
+// Node returns the node iter points to, or nil if iter is invalid
+func (iter *RbTreeIterator) Node() *Node {
+	return iter.node
+}
+
 // IsValid returns whether iter is valid or not
 func (iter *RbTreeIterator) IsValid() bool {
 	if iter.node != nil {
@@ -15,6 +15,7 @@ type Node struct {
 	left   *Node
 	right  *Node
 	color  Color
+	size   int // the number of nodes in the subtree rooted at this node
 	key    interface{}
 	value  interface{}
 }
@@ -82,6 +83,14 @@ func minimum(n *Node) *Node {
 	return n
 }
 
+// getSize returns the size of subtree n.
+func getSize(n *Node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 // maximum finds the maximum Node of subtree n.
 func maximum(n *Node) *Node {
 	for n.right != nil {
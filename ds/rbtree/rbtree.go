@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/visitor"
+	"sync"
 )
 
 var (
@@ -12,7 +13,8 @@ var (
 
 // Options holds RbTree's options
 type Options struct {
-	keyCmp comparator.Comparator
+	keyCmp   comparator.Comparator
+	nodePool bool
 }
 
 // Option is a function used to set Options
@@ -25,6 +27,14 @@ func WithKeyComparator(cmp comparator.Comparator) Option {
 	}
 }
 
+// WithNodePool recycles the deleted nodes by a sync.Pool to reduce the allocations when nodes are frequently inserted and deleted.
+// Note that a deleted node may be reused by a later Insert, so the Node and iterators pointing to it must not be used after deleting.
+func WithNodePool() Option {
+	return func(option *Options) {
+		option.nodePool = true
+	}
+}
+
 // RbTree is a kind of self-balancing binary search tree in computer science.
 // Each node of the binary tree has an extra bit, and that bit is often interpreted
 // as the color (red or black) of the node. These color bits are used to ensure the tree
@@ -33,6 +43,7 @@ type RbTree struct {
 	root   *Node
 	size   int
 	keyCmp comparator.Comparator
+	pool   *sync.Pool
 }
 
 //New news a RbTree
@@ -43,15 +54,120 @@ func New(opts ...Option) *RbTree {
 	for _, opt := range opts {
 		opt(&option)
 	}
-	return &RbTree{keyCmp: option.keyCmp}
+	t := &RbTree{keyCmp: option.keyCmp}
+	if option.nodePool {
+		t.pool = newPool()
+	}
+	return t
+}
+
+func newPool() *sync.Pool {
+	return &sync.Pool{
+		New: func() interface{} {
+			return &Node{}
+		},
+	}
+}
+
+// NewFromSorted news a RbTree from keys and their related values, it builds a balanced tree in O(n) time.
+// keys must be sorted in ascending order by the key comparator, otherwise it panics.
+func NewFromSorted(keys, values []interface{}, opts ...Option) *RbTree {
+	if len(keys) != len(values) {
+		panic(fmt.Sprintf("rbtree: keys and values have different lengths %v and %v", len(keys), len(values)))
+	}
+	t := New(opts...)
+	for i := 1; i < len(keys); i++ {
+		if t.keyCmp(keys[i-1], keys[i]) > 0 {
+			panic(fmt.Sprintf("rbtree: keys are not sorted at position %v", i))
+		}
+	}
+	maxDepth := -1
+	for n := len(keys); n > 0; n >>= 1 {
+		maxDepth++
+	}
+	// the nodes at the deepest level are red only if the deepest level is not full,
+	// so that every path from the root to a leaf has the same number of black nodes
+	redDepth := -1
+	if len(keys) != 1<<uint(maxDepth+1)-1 {
+		redDepth = maxDepth
+	}
+	t.root = buildFromSorted(keys, values, nil, 0, redDepth)
+	t.size = len(keys)
+	return t
+}
+
+func buildFromSorted(keys, values []interface{}, parent *Node, depth, redDepth int) *Node {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	n := &Node{parent: parent, color: BLACK, size: len(keys), key: keys[mid], value: values[mid]}
+	if depth == redDepth {
+		n.color = RED
+	}
+	n.left = buildFromSorted(keys[:mid], values[:mid], n, depth+1, redDepth)
+	n.right = buildFromSorted(keys[mid+1:], values[mid+1:], n, depth+1, redDepth)
+	return n
 }
 
-// Clear clears the tree
+// Clear clears the tree. If the tree uses a node pool, the nodes are put back to the pool in O(n) time,
+// so that they are reused by the following inserts, otherwise it takes O(1) time.
 func (t *RbTree) Clear() {
+	if t.pool != nil {
+		t.release(t.root, t.freeNode)
+	}
+	t.root = nil
+	t.size = 0
+}
+
+// Reset clears the tree and releases all the memory it references in O(n) time: the links, keys and values of the nodes
+// are cleared, so that a node still referenced by an iterator doesn't keep the others alive, and the node pool is dropped.
+// It suits a large tree which will not grow back soon, use Clear to reuse the nodes instead.
+func (t *RbTree) Reset() {
+	t.release(t.root, func(n *Node) {
+		*n = Node{}
+	})
+	if t.pool != nil {
+		t.pool = newPool()
+	}
 	t.root = nil
 	t.size = 0
 }
 
+// release calls free for every node of the subtree n in post-order, so that the children are visited before being unlinked
+func (t *RbTree) release(n *Node, free func(n *Node)) {
+	if n == nil {
+		return
+	}
+	t.release(n.left, free)
+	t.release(n.right, free)
+	free(n)
+}
+
+// Clone returns a copy of the tree with the same shape and colors, it takes O(n) time.
+// The keys and values are shallow copied. If the tree uses a node pool, the copy uses a new node pool of its own.
+func (t *RbTree) Clone() *RbTree {
+	c := &RbTree{
+		root:   cloneNode(t.root, nil),
+		size:   t.size,
+		keyCmp: t.keyCmp,
+	}
+	if t.pool != nil {
+		c.pool = newPool()
+	}
+	return c
+}
+
+func cloneNode(n, parent *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	c := &Node{parent: parent, color: n.color, size: n.size, key: n.key, value: n.value}
+	c.left = cloneNode(n.left, c)
+	c.right = cloneNode(n.right, c)
+	return c
+}
+
 // Find finds the first Node by the key and return its value.
 func (t *RbTree) Find(key interface{}) interface{} {
 	n := t.findFirstNode(key)
@@ -97,11 +213,16 @@ func (t *RbTree) IterFirst() *RbTreeIterator {
 	return NewIterator(t.First())
 }
 
-// IterLast returns the iterator of first Node
+// IterLast returns the iterator of last Node
 func (t *RbTree) IterLast() *RbTreeIterator {
 	return NewIterator(t.Last())
 }
 
+// IterFind returns the iterator of the first Node with the key, or an invalid iterator if not exists
+func (t *RbTree) IterFind(key interface{}) *RbTreeIterator {
+	return NewIterator(t.findFirstNode(key))
+}
+
 // Empty returns true if Tree is empty,otherwise returns false.
 func (t *RbTree) Empty() bool {
 	if t.size == 0 {
@@ -122,6 +243,7 @@ func (t *RbTree) Insert(key, value interface{}) {
 
 	for x != nil {
 		y = x
+		y.size++
 		if t.keyCmp(key, x.key) < 0 {
 			x = x.left
 		} else {
@@ -129,7 +251,8 @@ func (t *RbTree) Insert(key, value interface{}) {
 		}
 	}
 
-	z := &Node{parent: y, color: RED, key: key, value: value}
+	z := t.newNode()
+	z.parent, z.color, z.size, z.key, z.value = y, RED, 1, key, value
 	t.size++
 
 	if y == nil {
@@ -216,15 +339,79 @@ func (t *RbTree) Delete(node *Node) {
 		y.parent.right = x
 	}
 
+	color := y.color
 	if y != z {
-		z.key = y.key
-		z.value = y.value
+		// move y to the position of z rather than copying its key and value into z,
+		// so that the other nodes (and the iterators pointing to them) remain valid
+		if xparent == z {
+			xparent = y
+		}
+		t.replaceNode(z, y)
+	}
+
+	for p := xparent; p != nil; p = p.parent {
+		p.size--
 	}
 
-	if y.color == BLACK {
+	if color == BLACK {
 		t.rbDeleteFixup(x, xparent)
 	}
 	t.size--
+	t.freeNode(z)
+}
+
+func (t *RbTree) newNode() *Node {
+	if t.pool == nil {
+		return &Node{}
+	}
+	return t.pool.Get().(*Node)
+}
+
+// freeNode puts n back to the pool after resetting all of its fields, so that the pool doesn't keep the old keys and values alive
+func (t *RbTree) freeNode(n *Node) {
+	if t.pool == nil {
+		return
+	}
+	*n = Node{}
+	t.pool.Put(n)
+}
+
+// DeleteIf deletes all the nodes for which pred returns true in one pass and returns the number of deleted nodes
+func (t *RbTree) DeleteIf(pred func(key, value interface{}) bool) int {
+	count := 0
+	node := t.First()
+	for node != nil {
+		// Delete doesn't move other nodes, so the next node stays valid after deleting the current one
+		next := node.Next()
+		if pred(node.Key(), node.Value()) {
+			t.Delete(node)
+			count++
+		}
+		node = next
+	}
+	return count
+}
+
+// replaceNode puts y at the position of z in the tree, y takes over the children, color and size of z
+func (t *RbTree) replaceNode(z, y *Node) {
+	y.parent = z.parent
+	if z.parent == nil {
+		t.root = y
+	} else if z == z.parent.left {
+		z.parent.left = y
+	} else {
+		z.parent.right = y
+	}
+	y.left = z.left
+	y.right = z.right
+	if y.left != nil {
+		y.left.parent = y
+	}
+	if y.right != nil {
+		y.right.parent = y
+	}
+	y.color = z.color
+	y.size = z.size
 }
 
 func (t *RbTree) rbDeleteFixup(x, parent *Node) {
@@ -322,6 +509,9 @@ func (t *RbTree) leftRotate(x *Node) {
 	}
 	y.left = x
 	x.parent = y
+
+	y.size = x.size
+	x.size = getSize(x.left) + getSize(x.right) + 1
 }
 
 func (t *RbTree) rightRotate(x *Node) {
@@ -340,6 +530,9 @@ func (t *RbTree) rightRotate(x *Node) {
 	}
 	y.right = x
 	x.parent = y
+
+	y.size = x.size
+	x.size = getSize(x.left) + getSize(x.right) + 1
 }
 
 // findNode finds the Node by key and return it's Node, if not exists return nil.
@@ -392,6 +585,60 @@ func (t *RbTree) findLowerBoundNode(x *Node, key interface{}) *Node {
 	return t.findLowerBoundNode(x.right, key)
 }
 
+// FindUpperBoundNode returns the first Node that greater than key, if not exists return nil.
+func (t *RbTree) FindUpperBoundNode(key interface{}) *Node {
+	return t.findUpperBoundNode(t.root, key)
+}
+
+func (t *RbTree) findUpperBoundNode(x *Node, key interface{}) *Node {
+	if x == nil {
+		return nil
+	}
+	if t.keyCmp(key, x.key) >= 0 {
+		return t.findUpperBoundNode(x.right, key)
+	}
+	ret := t.findUpperBoundNode(x.left, key)
+	if ret == nil {
+		return x
+	}
+	return ret
+}
+
+// Select returns the k-th (0-indexed) smallest Node in the tree, if k is out of range return nil.
+func (t *RbTree) Select(k int) *Node {
+	if k < 0 || k >= t.size {
+		return nil
+	}
+	x := t.root
+	for x != nil {
+		leftSize := getSize(x.left)
+		if k < leftSize {
+			x = x.left
+		} else if k == leftSize {
+			return x
+		} else {
+			k -= leftSize + 1
+			x = x.right
+		}
+	}
+	return nil
+}
+
+// Rank returns the number of keys strictly less than key in the tree.
+func (t *RbTree) Rank(key interface{}) int {
+	rank := 0
+	x := t.root
+	for x != nil {
+		if t.keyCmp(key, x.key) <= 0 {
+			x = x.left
+		} else {
+			rank += getSize(x.left) + 1
+			x = x.right
+		}
+	}
+	return rank
+}
+
 // Traversal traversals elements in rbtree, it will not stop until to the end or visitor returns false
 func (t *RbTree) Traversal(visitor visitor.KvVisitor) {
 	for node := t.First(); node != nil; node = node.Next() {
@@ -401,6 +648,32 @@ func (t *RbTree) Traversal(visitor visitor.KvVisitor) {
 	}
 }
 
+// TraversalRange traversals elements with lower <= key < upper in the tree in ascending order of keys,
+// it will not stop until to the end of the range or visitor returns false.
+// Subtrees out of the range are skipped, so it takes O(log n + m) time, m is the number of elements in the range.
+func (t *RbTree) TraversalRange(lower, upper interface{}, visitor visitor.KvVisitor) {
+	t.traversalRange(t.root, lower, upper, visitor)
+}
+
+func (t *RbTree) traversalRange(x *Node, lower, upper interface{}, visitor visitor.KvVisitor) bool {
+	if x == nil {
+		return true
+	}
+	aboveLower := t.keyCmp(x.key, lower) >= 0
+	belowUpper := t.keyCmp(x.key, upper) < 0
+	// all keys in the left subtree are not greater than x.key, and all keys in the right subtree are not less than x.key
+	if aboveLower && !t.traversalRange(x.left, lower, upper, visitor) {
+		return false
+	}
+	if aboveLower && belowUpper && !visitor(x.key, x.value) {
+		return false
+	}
+	if belowUpper {
+		return t.traversalRange(x.right, lower, upper, visitor)
+	}
+	return true
+}
+
 // IsRbTree is a function use to test whether t is a RbTree or not
 func (t *RbTree) IsRbTree() (bool, error) {
 	// Properties:
@@ -453,6 +726,90 @@ func (t *RbTree) test(n *Node) (int, int, bool) {
 	return blackCount, 0, true
 }
 
+// Height returns the maximum depth of the tree, an empty tree has height 0.
+func (t *RbTree) Height() int {
+	return height(t.root)
+}
+
+func height(n *Node) int {
+	if n == nil {
+		return 0
+	}
+	left := height(n.left)
+	right := height(n.right)
+	if left > right {
+		return left + 1
+	}
+	return right + 1
+}
+
+// Verify checks whether t satisfies all properties of a RbTree and the binary search tree ordering,
+// it returns an error which describes the first violation found, or nil if t is valid.
+func (t *RbTree) Verify() error {
+	if t.root == nil {
+		if t.size != 0 {
+			return fmt.Errorf("empty tree has size %v", t.size)
+		}
+		return nil
+	}
+	if t.root.parent != nil {
+		return fmt.Errorf("root %v has a parent", t.root.key)
+	}
+	if t.root.color != BLACK {
+		return fmt.Errorf("root %v is not black", t.root.key)
+	}
+	if _, err := t.verify(t.root); err != nil {
+		return err
+	}
+	if t.root.size != t.size {
+		return fmt.Errorf("tree size %v is not equal to the number of nodes %v", t.size, t.root.size)
+	}
+	var prev *Node
+	for n := t.First(); n != nil; n = n.Next() {
+		if prev != nil && t.keyCmp(prev.key, n.key) > 0 {
+			return fmt.Errorf("key %v is greater than its successor %v", prev.key, n.key)
+		}
+		prev = n
+	}
+	return nil
+}
+
+// verify checks subtree n and returns its black height
+func (t *RbTree) verify(n *Node) (int, error) {
+	if n == nil {
+		return 1, nil
+	}
+	for _, child := range []*Node{n.left, n.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != n {
+			return 0, fmt.Errorf("the parent of node %v is not %v", child.key, n.key)
+		}
+		if n.color == RED && child.color == RED {
+			return 0, fmt.Errorf("red node %v has a red child %v", n.key, child.key)
+		}
+	}
+	leftBlackHeight, err := t.verify(n.left)
+	if err != nil {
+		return 0, err
+	}
+	rightBlackHeight, err := t.verify(n.right)
+	if err != nil {
+		return 0, err
+	}
+	if leftBlackHeight != rightBlackHeight {
+		return 0, fmt.Errorf("node %v has different black heights %v and %v", n.key, leftBlackHeight, rightBlackHeight)
+	}
+	if n.size != getSize(n.left)+getSize(n.right)+1 {
+		return 0, fmt.Errorf("node %v has wrong subtree size %v", n.key, n.size)
+	}
+	if n.color == BLACK {
+		return leftBlackHeight + 1, nil
+	}
+	return leftBlackHeight, nil
+}
+
 // getColor gets color of the Node.
 func getColor(n *Node) Color {
 	if n == nil {
@@ -1,9 +1,12 @@
 package rbtree
 
 import (
+	"fmt"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/stretchr/testify/assert"
+	"math/bits"
 	"math/rand"
+	"sort"
 	"testing"
 	"time"
 )
@@ -132,6 +135,8 @@ func TestIterator(t *testing.T) {
 	assert.True(t, tree.IterFirst().Equal(tree.IterFirst().Clone()))
 	assert.False(t, tree.IterFirst().Equal(nil))
 	assert.False(t, tree.IterFirst().Equal(tree.IterLast()))
+	assert.Equal(t, tree.First(), tree.IterFirst().Node())
+	assert.Nil(t, tree.IterFind(100).Node())
 }
 
 func TestNode(t *testing.T) {
@@ -162,3 +167,415 @@ func TestNode(t *testing.T) {
 		i++
 	}
 }
+
+func TestFindUpperBoundNode(t *testing.T) {
+	tree := New()
+	assert.Nil(t, tree.FindUpperBoundNode(1))
+
+	for i := 0; i < 20; i += 2 {
+		tree.Insert(i, i+100)
+		tree.Insert(i, i+200)
+	}
+	assert.Equal(t, 0, tree.FindUpperBoundNode(-1).Key())
+	for i := 0; i < 18; i++ {
+		node := tree.FindUpperBoundNode(i)
+		assert.Equal(t, i/2*2+2, node.Key())
+		assert.Equal(t, i/2*2+102, node.Value())
+	}
+	assert.Nil(t, tree.FindUpperBoundNode(18))
+	assert.Nil(t, tree.FindUpperBoundNode(100))
+}
+
+func TestClone(t *testing.T) {
+	tree := New()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i+100)
+	}
+	c := tree.Clone()
+	b, _ := c.IsRbTree()
+	assert.True(t, b)
+	assert.Equal(t, 100, c.Size())
+
+	for i := 0; i < 100; i += 2 {
+		c.Delete(c.FindNode(i))
+	}
+	c.FindNode(1).SetValue(0)
+	assert.Equal(t, 50, c.Size())
+	assert.Equal(t, 100, tree.Size())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i+100, tree.Find(i))
+	}
+	b, _ = tree.IsRbTree()
+	assert.True(t, b)
+}
+
+func TestCloneWithNodePool(t *testing.T) {
+	tree := New(WithNodePool())
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	c := tree.Clone()
+	assert.NotNil(t, c.pool)
+	assert.True(t, c.pool != tree.pool)
+	assert.Nil(t, New().Clone().pool)
+
+	for i := 0; i < 10; i++ {
+		c.Delete(c.FindNode(i))
+		c.Insert(i+10, i)
+	}
+	assert.Nil(t, c.Verify())
+	assert.Equal(t, 10, c.Size())
+	assert.Equal(t, 0, tree.Find(0))
+}
+
+func TestNewFromSorted(t *testing.T) {
+	for n := 0; n < 300; n++ {
+		keys := make([]interface{}, n)
+		values := make([]interface{}, n)
+		for i := 0; i < n; i++ {
+			keys[i] = i
+			values[i] = i + 100
+		}
+		tree := NewFromSorted(keys, values)
+		b, err := tree.IsRbTree()
+		assert.True(t, b, "n=%v err=%v", n, err)
+		assert.Equal(t, n, tree.Size())
+
+		i := 0
+		for node := tree.First(); node != nil; node = node.Next() {
+			assert.Equal(t, i, node.Key())
+			assert.Equal(t, i+100, node.Value())
+			i++
+		}
+		assert.Equal(t, n, i)
+
+		tree.Insert(n, n)
+		tree.Delete(tree.FindNode(0))
+		b, _ = tree.IsRbTree()
+		assert.True(t, b)
+	}
+
+	tree := NewFromSorted([]interface{}{3, 2, 1}, []interface{}{1, 2, 3}, WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	assert.Equal(t, 3, tree.First().Key())
+
+	assert.Panics(t, func() { NewFromSorted([]interface{}{1, 3, 2}, []interface{}{1, 2, 3}) })
+	assert.Panics(t, func() { NewFromSorted([]interface{}{1, 2}, []interface{}{1}) })
+}
+
+func TestSelectRank(t *testing.T) {
+	tree := New()
+	assert.Nil(t, tree.Select(0))
+	assert.Equal(t, 0, tree.Rank(1))
+
+	m := make(map[int]bool)
+	for i := 0; i < 3000; i++ {
+		key := rand.Intn(500)
+		if m[key] {
+			delete(m, key)
+			tree.Delete(tree.FindNode(key))
+		} else {
+			m[key] = true
+			tree.Insert(key, key)
+		}
+		if i%100 != 0 {
+			continue
+		}
+		sorted := make([]int, 0, len(m))
+		for k := range m {
+			sorted = append(sorted, k)
+		}
+		sort.Ints(sorted)
+		assert.Equal(t, len(sorted), tree.Size())
+		for k, key := range sorted {
+			assert.Equal(t, key, tree.Select(k).Key())
+		}
+		assert.Nil(t, tree.Select(-1))
+		assert.Nil(t, tree.Select(len(sorted)))
+		for key := -1; key <= 500; key++ {
+			assert.Equal(t, sort.SearchInts(sorted, key), tree.Rank(key))
+		}
+	}
+
+	keys := []interface{}{1, 3, 5, 7}
+	tree = NewFromSorted(keys, keys).Clone()
+	for k, key := range keys {
+		assert.Equal(t, key, tree.Select(k).Key())
+		assert.Equal(t, k, tree.Rank(key))
+	}
+}
+
+func TestIteratorShuffled(t *testing.T) {
+	tree := New()
+	for _, i := range rand.Perm(100) {
+		tree.Insert(i, i*2)
+	}
+
+	i := 0
+	for iter := tree.IterFirst(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, i, iter.Key())
+		assert.Equal(t, i*2, iter.Value())
+		i++
+	}
+	assert.Equal(t, 100, i)
+
+	for iter := tree.IterLast(); iter.IsValid(); iter.Prev() {
+		i--
+		assert.Equal(t, i, iter.Key())
+	}
+	assert.Equal(t, 0, i)
+
+	iter := tree.IterFind(50)
+	assert.Equal(t, 50, iter.Key())
+	iter.Next()
+	assert.Equal(t, 51, iter.Key())
+	iter.Prev().Prev()
+	assert.Equal(t, 49, iter.Key())
+	assert.False(t, tree.IterFind(100).IsValid())
+}
+
+func benchmarkInsert(b *testing.B, opts ...Option) {
+	keys := rand.Perm(1000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree := New(opts...)
+		for _, key := range keys {
+			tree.Insert(key, key)
+		}
+	}
+}
+
+func BenchmarkInsertBuiltinTypeComparator(b *testing.B) {
+	benchmarkInsert(b)
+}
+
+func BenchmarkInsertIntComparator(b *testing.B) {
+	benchmarkInsert(b, WithKeyComparator(comparator.IntComparator))
+}
+
+func TestHeightVerify(t *testing.T) {
+	tree := New()
+	assert.Equal(t, 0, tree.Height())
+	assert.Nil(t, tree.Verify())
+
+	tree.Insert(1, 1)
+	assert.Equal(t, 1, tree.Height())
+
+	for _, key := range rand.Perm(10000) {
+		tree.Insert(key, key)
+	}
+	assert.Nil(t, tree.Verify())
+	for i := 0; i < 9000; i++ {
+		tree.Delete(tree.FindNode(rand.Intn(10000)))
+	}
+	assert.Nil(t, tree.Verify())
+	// the height of a RbTree is at most 2*log2(n+1)
+	assert.True(t, tree.Height() <= 2*bits.Len(uint(tree.Size()+1)))
+
+	tree = New()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	tree.root.color = RED
+	assert.NotNil(t, tree.Verify())
+	tree.root.color = BLACK
+	assert.Nil(t, tree.Verify())
+
+	tree.First().key = 100
+	assert.NotNil(t, tree.Verify())
+	tree.First().key = 0
+
+	tree.Last().color = BLACK
+	assert.NotNil(t, tree.Verify())
+}
+
+func TestDeleteKeepsOtherNodes(t *testing.T) {
+	tree := New()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i)
+	}
+	// deleting a node with two children must not invalidate its successor
+	for tree.Size() > 0 {
+		node := tree.root
+		next := node.Next()
+		tree.Delete(node)
+		assert.Nil(t, tree.Verify())
+		if next != nil {
+			assert.True(t, next == tree.FindNode(next.Key()))
+		}
+	}
+}
+
+func TestTraversalRange(t *testing.T) {
+	compares := 0
+	tree := New(WithKeyComparator(func(a, b interface{}) int {
+		compares++
+		return comparator.StringComparator(a, b)
+	}))
+	words := []string{"apple", "apricot", "banana", "app", "ap", "b", "aq", "application", "a"}
+	for i := 0; i < 10000; i++ {
+		words = append(words, fmt.Sprintf("z%05d", i))
+	}
+	for _, word := range words {
+		tree.Insert(word, len(word))
+	}
+
+	// prefix scan of "ap", "aq" is the successor of the prefix
+	var keys []interface{}
+	compares = 0
+	tree.TraversalRange("ap", "aq", func(key, value interface{}) bool {
+		assert.Equal(t, len(key.(string)), value)
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{"ap", "app", "apple", "application", "apricot"}, keys)
+	// subtrees out of range are pruned
+	assert.True(t, compares < 200, "compares %v", compares)
+
+	keys = nil
+	tree.TraversalRange("z00010", "z00013", func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []interface{}{"z00010", "z00011", "z00012"}, keys)
+
+	n := 0
+	tree.TraversalRange("a", "z", func(key, value interface{}) bool {
+		n++
+		return n < 3
+	})
+	assert.Equal(t, 3, n)
+
+	tree.TraversalRange("c", "a", func(key, value interface{}) bool {
+		t.Fatalf("unexpected key %v", key)
+		return true
+	})
+}
+
+func TestDeleteIf(t *testing.T) {
+	tree := New()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i*10)
+	}
+	n := tree.DeleteIf(func(key, value interface{}) bool {
+		return key.(int)%2 == 0
+	})
+	assert.Equal(t, 500, n)
+	assert.Equal(t, 500, tree.Size())
+	assert.Nil(t, tree.Verify())
+
+	i := 1
+	for node := tree.First(); node != nil; node = node.Next() {
+		assert.Equal(t, i, node.Key())
+		assert.Equal(t, i*10, node.Value())
+		i += 2
+	}
+	assert.Equal(t, 1001, i)
+
+	assert.Equal(t, 0, tree.DeleteIf(func(key, value interface{}) bool { return false }))
+	assert.Equal(t, 500, tree.DeleteIf(func(key, value interface{}) bool { return true }))
+	assert.True(t, tree.Empty())
+	assert.Nil(t, tree.Verify())
+}
+
+func TestNodePool(t *testing.T) {
+	tree := New(WithNodePool())
+	type payload struct{ data [64]byte }
+	key, value := 1, &payload{}
+	tree.Insert(key, value)
+	node := tree.FindNode(key)
+	tree.Delete(node)
+	// the recycled node must not retain the old key, value and links
+	assert.Nil(t, node.key)
+	assert.Nil(t, node.value)
+	assert.True(t, node.parent == nil && node.left == nil && node.right == nil)
+	assert.Equal(t, 0, node.size)
+
+	for i := 0; i < 10000; i++ {
+		tree.Insert(rand.Intn(1000), i)
+		if n := tree.FindNode(rand.Intn(1000)); n != nil {
+			tree.Delete(n)
+		}
+	}
+	assert.Nil(t, tree.Verify())
+}
+
+func TestClearReset(t *testing.T) {
+	tree := New()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	node := tree.FindNode(50)
+	tree.Clear()
+	assert.Equal(t, 0, tree.Size())
+	assert.Nil(t, tree.First())
+	// without a node pool, Clear just drops the root
+	assert.Equal(t, 50, node.key)
+
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	node = tree.FindNode(50)
+	tree.Reset()
+	assert.Equal(t, 0, tree.Size())
+	assert.Nil(t, tree.First())
+	// Reset releases the references of the nodes, so that a kept node doesn't keep the tree alive
+	assert.Nil(t, node.key)
+	assert.Nil(t, node.value)
+	assert.True(t, node.parent == nil && node.left == nil && node.right == nil)
+
+	tree.Insert(1, 1)
+	assert.Equal(t, 1, tree.Size())
+	assert.Nil(t, tree.Verify())
+}
+
+func TestClearResetWithNodePool(t *testing.T) {
+	tree := New(WithNodePool())
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	node := tree.FindNode(50)
+	pool := tree.pool
+	tree.Clear()
+	assert.Equal(t, 0, tree.Size())
+	// the nodes are put back to the pool for reuse
+	assert.Nil(t, node.key)
+	assert.True(t, node.parent == nil && node.left == nil && node.right == nil)
+	assert.True(t, pool == tree.pool)
+
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	assert.Nil(t, tree.Verify())
+	tree.Reset()
+	assert.Equal(t, 0, tree.Size())
+	// the pooled nodes are released together with the old pool
+	assert.True(t, pool != tree.pool)
+	tree.Insert(1, 1)
+	assert.Nil(t, tree.Verify())
+}
+
+func benchmarkInsertDelete(b *testing.B, opts ...Option) {
+	keys := make([]interface{}, 1000)
+	for i, key := range rand.Perm(len(keys)) {
+		keys[i] = key
+	}
+	tree := New(opts...)
+	for _, key := range keys[:len(keys)/2] {
+		tree.Insert(key, key)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[len(keys)/2+i%(len(keys)/2)]
+		tree.Insert(key, key)
+		tree.Delete(tree.FindNode(key))
+	}
+}
+
+func BenchmarkInsertDelete(b *testing.B) {
+	benchmarkInsertDelete(b)
+}
+
+func BenchmarkInsertDeleteWithNodePool(b *testing.B) {
+	benchmarkInsertDelete(b, WithNodePool())
+}
@@ -0,0 +1,189 @@
+package ringbuffer
+
+import (
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds RingBuffer's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets RingBuffer goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// RingBuffer is a circular buffer with a fixed capacity, it keeps the most recent values pushed to it
+type RingBuffer struct {
+	data   []interface{}
+	head   int // the position of the oldest value
+	size   int
+	locker sync.Locker
+}
+
+// New creates a new RingBuffer holding at most capacity values, it panics if capacity is not positive
+func New(capacity int, opts ...Option) *RingBuffer {
+	if capacity <= 0 {
+		panic("ringbuffer: capacity must be positive")
+	}
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &RingBuffer{
+		data:   make([]interface{}, capacity),
+		locker: option.locker,
+	}
+}
+
+// Push pushes value to the RingBuffer as the newest value, the oldest value is overwritten if the RingBuffer is full
+func (rb *RingBuffer) Push(value interface{}) {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	if rb.size == len(rb.data) {
+		rb.data[rb.head] = value
+		rb.head = rb.index(1)
+		return
+	}
+	rb.data[rb.index(rb.size)] = value
+	rb.size++
+}
+
+// PushNoOverwrite pushes value to the RingBuffer and returns true if the RingBuffer is not full, otherwise returns false
+func (rb *RingBuffer) PushNoOverwrite(value interface{}) bool {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	if rb.size == len(rb.data) {
+		return false
+	}
+	rb.data[rb.index(rb.size)] = value
+	rb.size++
+	return true
+}
+
+// PopOldest removes the oldest value from the RingBuffer and returns it, ok is false if the RingBuffer is empty
+func (rb *RingBuffer) PopOldest() (interface{}, bool) {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	if rb.size == 0 {
+		return nil, false
+	}
+	value := rb.data[rb.head]
+	rb.data[rb.head] = nil
+	rb.head = rb.index(1)
+	rb.size--
+	return value, true
+}
+
+// Oldest returns the oldest value in the RingBuffer, ok is false if the RingBuffer is empty
+func (rb *RingBuffer) Oldest() (interface{}, bool) {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	if rb.size == 0 {
+		return nil, false
+	}
+	return rb.data[rb.head], true
+}
+
+// Newest returns the newest value in the RingBuffer, ok is false if the RingBuffer is empty
+func (rb *RingBuffer) Newest() (interface{}, bool) {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	if rb.size == 0 {
+		return nil, false
+	}
+	return rb.data[rb.index(rb.size-1)], true
+}
+
+// At returns the n-th (0-indexed) oldest value, ok is false if n is out of range
+func (rb *RingBuffer) At(n int) (interface{}, bool) {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	if n < 0 || n >= rb.size {
+		return nil, false
+	}
+	return rb.data[rb.index(n)], true
+}
+
+// Len returns the number of values in the RingBuffer
+func (rb *RingBuffer) Len() int {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	return rb.size
+}
+
+// Cap returns the capacity of the RingBuffer
+func (rb *RingBuffer) Cap() int {
+	return len(rb.data)
+}
+
+// Full returns true if the RingBuffer is full, otherwise returns false
+func (rb *RingBuffer) Full() bool {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	return rb.size == len(rb.data)
+}
+
+// Clear removes all the values in the RingBuffer
+func (rb *RingBuffer) Clear() {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	for i := range rb.data {
+		rb.data[i] = nil
+	}
+	rb.head = 0
+	rb.size = 0
+}
+
+// Values returns all the values from the oldest to the newest
+func (rb *RingBuffer) Values() []interface{} {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	values := make([]interface{}, 0, rb.size)
+	for i := 0; i < rb.size; i++ {
+		values = append(values, rb.data[rb.index(i)])
+	}
+	return values
+}
+
+// Traversal traversals the values from the oldest to the newest, it will not stop until to the end or visitor returns false.
+// Note that the RingBuffer is read-locked during the traversal, so visitor must not modify the RingBuffer.
+func (rb *RingBuffer) Traversal(visitor visitor.Visitor) {
+	rb.locker.RLock()
+	defer rb.locker.RUnlock()
+
+	for i := 0; i < rb.size; i++ {
+		if !visitor(rb.data[rb.index(i)]) {
+			break
+		}
+	}
+}
+
+// index returns the position in data of the n-th oldest value
+func (rb *RingBuffer) index(n int) int {
+	return (rb.head + n) % len(rb.data)
+}
@@ -0,0 +1,72 @@
+package ringbuffer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	rb := New(3)
+	assert.Equal(t, 3, rb.Cap())
+	assert.Equal(t, 0, rb.Len())
+	_, ok := rb.Oldest()
+	assert.False(t, ok)
+	_, ok = rb.Newest()
+	assert.False(t, ok)
+
+	for i := 1; i <= 7; i++ {
+		rb.Push(i)
+		if i < 3 {
+			assert.Equal(t, i, rb.Len())
+		} else {
+			assert.Equal(t, 3, rb.Len())
+		}
+	}
+	// the most recent 3 values are retained
+	assert.Equal(t, []interface{}{5, 6, 7}, rb.Values())
+	assert.True(t, rb.Full())
+	oldest, ok := rb.Oldest()
+	assert.True(t, ok)
+	assert.Equal(t, 5, oldest)
+	newest, ok := rb.Newest()
+	assert.True(t, ok)
+	assert.Equal(t, 7, newest)
+	v, ok := rb.At(1)
+	assert.True(t, ok)
+	assert.Equal(t, 6, v)
+	_, ok = rb.At(3)
+	assert.False(t, ok)
+
+	var visited []interface{}
+	rb.Traversal(func(value interface{}) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+	assert.Equal(t, []interface{}{5, 6}, visited)
+}
+
+func TestRingBufferNoOverwrite(t *testing.T) {
+	rb := New(2, WithGoroutineSafe())
+	assert.True(t, rb.PushNoOverwrite(1))
+	assert.True(t, rb.PushNoOverwrite(2))
+	assert.False(t, rb.PushNoOverwrite(3))
+	assert.Equal(t, []interface{}{1, 2}, rb.Values())
+
+	v, ok := rb.PopOldest()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.True(t, rb.PushNoOverwrite(3))
+	assert.Equal(t, []interface{}{2, 3}, rb.Values())
+
+	rb.Push(4)
+	assert.Equal(t, []interface{}{3, 4}, rb.Values())
+
+	rb.Clear()
+	assert.Equal(t, 0, rb.Len())
+	_, ok = rb.PopOldest()
+	assert.False(t, ok)
+	rb.Push(5)
+	assert.Equal(t, []interface{}{5}, rb.Values())
+
+	assert.Panics(t, func() { New(0) })
+}
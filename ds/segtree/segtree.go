@@ -0,0 +1,119 @@
+package segtree
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds SegTree's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets SegTree goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// SegTree is a segment tree over a fixed number of int64 values, it supports updating a value and querying the combined
+// value of a range in O(log n) time. The aggregate is decided by the combine function, e.g. sum, min or max.
+type SegTree struct {
+	// tree[n+i] holds the i-th value, and tree[i] holds combine(tree[2i], tree[2i+1]) for 0 < i < n
+	tree     []int64
+	n        int
+	combine  func(a, b int64) int64
+	identity int64
+	locker   sync.Locker
+}
+
+// New creates a new SegTree with values in O(n) time. combine must be associative, and identity must satisfy
+// combine(identity, x) == combine(x, identity) == x for any x, e.g. 0 for sum and math.MaxInt64 for min.
+func New(values []int64, combine func(a, b int64) int64, identity int64, opts ...Option) *SegTree {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	n := len(values)
+	t := &SegTree{
+		tree:     make([]int64, 2*n),
+		n:        n,
+		combine:  combine,
+		identity: identity,
+		locker:   option.locker,
+	}
+	copy(t.tree[n:], values)
+	for i := n - 1; i > 0; i-- {
+		t.tree[i] = combine(t.tree[2*i], t.tree[2*i+1])
+	}
+	return t
+}
+
+// Update sets the i-th value to v, it panics if i is out of range
+func (t *SegTree) Update(i int, v int64) {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.checkIndex(i)
+	i += t.n
+	t.tree[i] = v
+	for i > 1 {
+		i /= 2
+		t.tree[i] = t.combine(t.tree[2*i], t.tree[2*i+1])
+	}
+}
+
+// Get returns the i-th value, it panics if i is out of range
+func (t *SegTree) Get(i int) int64 {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	t.checkIndex(i)
+	return t.tree[t.n+i]
+}
+
+// Query returns the combined value of the values in range [lo, hi) in order, or identity if the range is empty.
+// It panics if lo < 0 or hi > Len().
+func (t *SegTree) Query(lo, hi int) int64 {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	if lo < 0 || hi > t.n {
+		panic(fmt.Sprintf("segtree: range [%v, %v) out of range [0, %v)", lo, hi, t.n))
+	}
+	// the left and right parts are combined separately, so that combine doesn't need to be commutative
+	left, right := t.identity, t.identity
+	for lo, hi = lo+t.n, hi+t.n; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo&1 == 1 {
+			left = t.combine(left, t.tree[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			right = t.combine(t.tree[hi], right)
+		}
+	}
+	return t.combine(left, right)
+}
+
+// Len returns the number of values
+func (t *SegTree) Len() int {
+	return t.n
+}
+
+func (t *SegTree) checkIndex(i int) {
+	if i < 0 || i >= t.n {
+		panic(fmt.Sprintf("segtree: index %v out of range [0, %v)", i, t.n))
+	}
+}
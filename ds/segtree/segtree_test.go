@@ -0,0 +1,93 @@
+package segtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func sum(a, b int64) int64 {
+	return a + b
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func TestSumTree(t *testing.T) {
+	values := []int64{5, 3, 8, 1, 9, 2, 7}
+	st := New(values, sum, 0)
+	assert.Equal(t, 7, st.Len())
+	assert.Equal(t, int64(35), st.Query(0, 7))
+	assert.Equal(t, int64(12), st.Query(1, 4))
+	assert.Equal(t, int64(8), st.Query(2, 3))
+	assert.Equal(t, int64(0), st.Query(3, 3))
+	assert.Equal(t, int64(0), st.Query(5, 2))
+
+	st.Update(2, 10)
+	st.Update(6, -7)
+	st.Update(0, 0)
+	assert.Equal(t, int64(10), st.Get(2))
+	assert.Equal(t, int64(18), st.Query(0, 7))
+	assert.Equal(t, int64(10), st.Query(2, 3))
+	assert.Equal(t, int64(-5), st.Query(5, 7))
+
+	assert.Panics(t, func() { st.Query(0, 8) })
+	assert.Panics(t, func() { st.Update(7, 0) })
+	assert.Equal(t, int64(0), New(nil, sum, 0).Query(0, 0))
+}
+
+func TestMinTree(t *testing.T) {
+	st := New([]int64{4, 2, 6, 1, 5}, min, math.MaxInt64, WithGoroutineSafe())
+	assert.Equal(t, int64(1), st.Query(0, 5))
+	assert.Equal(t, int64(2), st.Query(0, 3))
+	assert.Equal(t, int64(6), st.Query(2, 3))
+	assert.Equal(t, int64(math.MaxInt64), st.Query(1, 1))
+
+	st.Update(3, 10)
+	st.Update(1, 7)
+	assert.Equal(t, int64(4), st.Query(0, 5))
+	assert.Equal(t, int64(5), st.Query(1, 5))
+	assert.Equal(t, int64(10), st.Query(3, 4))
+}
+
+func TestSegTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int64, 100)
+	for i := range values {
+		values[i] = r.Int63n(1000) - 500
+	}
+	sums := New(values, sum, 0)
+	mins := New(values, min, math.MaxInt64)
+	// a non-commutative combine: keeps the first value of the range
+	first := New(values, func(a, b int64) int64 {
+		if a == math.MinInt64 {
+			return b
+		}
+		return a
+	}, math.MinInt64)
+	for i := 0; i < 1000; i++ {
+		if r.Intn(2) == 0 {
+			j, v := r.Intn(len(values)), r.Int63n(1000)-500
+			values[j] = v
+			sums.Update(j, v)
+			mins.Update(j, v)
+			first.Update(j, v)
+			continue
+		}
+		lo := r.Intn(len(values))
+		hi := lo + 1 + r.Intn(len(values)-lo)
+		expectedSum, expectedMin := int64(0), int64(math.MaxInt64)
+		for _, v := range values[lo:hi] {
+			expectedSum += v
+			expectedMin = min(expectedMin, v)
+		}
+		assert.Equal(t, expectedSum, sums.Query(lo, hi))
+		assert.Equal(t, expectedMin, mins.Query(lo, hi))
+		assert.Equal(t, values[lo], first.Query(lo, hi))
+	}
+}
@@ -0,0 +1,37 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements gob.GobEncoder, the elements are encoded in ascending order.
+// The concrete types of elements other than the builtin types must be registered by gob.Register.
+func (s *Set) GobEncode() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(s.ToSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, it accepts the data generated by GobEncode.
+// The existing elements of the Set will be cleared.
+// Note that the comparator can't be encoded, so the Set must be created by New with the right
+// WithKeyComparator option before decoding into it.
+// A zero-value Set, e.g. allocated by encoding/gob for a *Set field, is initialized with the default options.
+func (s *Set) GobDecode(data []byte) error {
+	s.initZero()
+	var items []interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&items); err != nil {
+		return err
+	}
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.tree.Clear()
+	for _, element := range items {
+		s.insert(element)
+	}
+	return nil
+}
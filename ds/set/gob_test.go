@@ -0,0 +1,54 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type gobPoint struct {
+	X, Y int
+}
+
+func TestSetGob(t *testing.T) {
+	gob.Register(gobPoint{})
+	byX := func(a, b interface{}) int {
+		return comparator.IntComparator(a.(gobPoint).X, b.(gobPoint).X)
+	}
+
+	s := New(WithKeyComparator(byX))
+	s.Insert(gobPoint{3, 1}, gobPoint{1, 2}, gobPoint{2, 3})
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(s))
+
+	other := New(WithKeyComparator(byX), WithGoroutineSafe())
+	other.Insert(gobPoint{10, 10})
+	assert.Nil(t, gob.NewDecoder(buf).Decode(other))
+	assert.Equal(t, []interface{}{gobPoint{1, 2}, gobPoint{2, 3}, gobPoint{3, 1}}, other.ToSlice())
+
+	data, err := FromSlice([]interface{}{"b", "a"}).GobEncode()
+	assert.Nil(t, err)
+	strs := New()
+	assert.Nil(t, strs.GobDecode(data))
+	assert.Equal(t, []interface{}{"a", "b"}, strs.ToSlice())
+
+	assert.NotNil(t, strs.GobDecode([]byte("invalid")))
+}
+
+func TestSetGobField(t *testing.T) {
+	type wrapper struct {
+		S *Set
+	}
+	src := wrapper{S: FromSlice([]interface{}{3, 1, 2})}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(src))
+
+	var dst wrapper
+	assert.Nil(t, gob.NewDecoder(buf).Decode(&dst))
+	assert.Equal(t, []interface{}{1, 2, 3}, dst.S.ToSlice())
+	dst.S.Insert(0)
+	assert.Equal(t, 4, dst.S.Size())
+}
@@ -5,43 +5,40 @@ import (
 	"github.com/liyue201/gostl/utils/iterator"
 )
 
-// SetIterator is an iterator implementation of set
+// SetIterator is an iterator implementation of set, it moves over the nodes by an rbtree.RbTreeIterator
 type SetIterator struct {
-	node *rbtree.Node
+	cur rbtree.RbTreeIterator
+}
+
+func newSetIterator(node *rbtree.Node) *SetIterator {
+	return &SetIterator{cur: *rbtree.NewIterator(node)}
 }
 
 // IsValid returns whether iter is valid or not
 func (iter *SetIterator) IsValid() bool {
-	if iter.node != nil {
-		return true
-	}
-	return false
+	return iter.cur.IsValid()
 }
 
 // Next moves iter to next node and returns iter
 func (iter *SetIterator) Next() iterator.ConstIterator {
-	if iter.IsValid() {
-		iter.node = iter.node.Next()
-	}
+	iter.cur.Next()
 	return iter
 }
 
 // Prev moves iter to previous node and returns iter
 func (iter *SetIterator) Prev() iterator.ConstBidIterator {
-	if iter.IsValid() {
-		iter.node = iter.node.Prev()
-	}
+	iter.cur.Prev()
 	return iter
 }
 
 // Value returns the internal value of iter
 func (iter *SetIterator) Value() interface{} {
-	return iter.node.Key()
+	return iter.cur.Key()
 }
 
 // Clone clones iter to a new SetIterator
 func (iter *SetIterator) Clone() iterator.ConstIterator {
-	return &SetIterator{iter.node}
+	return newSetIterator(iter.cur.Node())
 }
 
 // Equal returns whether iter is equal to other or not
@@ -50,8 +47,50 @@ func (iter *SetIterator) Equal(other iterator.ConstIterator) bool {
 	if !ok {
 		return false
 	}
-	if otherIter.node == iter.node {
-		return true
+	return iter.cur.Equal(&otherIter.cur)
+}
+
+// SetReverseIterator is a reverse iterator implementation of set, it moves toward the smaller elements when calling Next
+type SetReverseIterator struct {
+	cur rbtree.RbTreeIterator
+}
+
+func newSetReverseIterator(node *rbtree.Node) *SetReverseIterator {
+	return &SetReverseIterator{cur: *rbtree.NewIterator(node)}
+}
+
+// IsValid returns whether iter is valid or not
+func (iter *SetReverseIterator) IsValid() bool {
+	return iter.cur.IsValid()
+}
+
+// Next moves iter to the next smaller node and returns iter
+func (iter *SetReverseIterator) Next() iterator.ConstIterator {
+	iter.cur.Prev()
+	return iter
+}
+
+// Prev moves iter to the next greater node and returns iter
+func (iter *SetReverseIterator) Prev() iterator.ConstBidIterator {
+	iter.cur.Next()
+	return iter
+}
+
+// Value returns the internal value of iter
+func (iter *SetReverseIterator) Value() interface{} {
+	return iter.cur.Key()
+}
+
+// Clone clones iter to a new SetReverseIterator
+func (iter *SetReverseIterator) Clone() iterator.ConstIterator {
+	return newSetReverseIterator(iter.cur.Node())
+}
+
+// Equal returns whether iter is equal to other or not
+func (iter *SetReverseIterator) Equal(other iterator.ConstIterator) bool {
+	otherIter, ok := other.(*SetReverseIterator)
+	if !ok {
+		return false
 	}
-	return false
+	return iter.cur.Equal(&otherIter.cur)
 }
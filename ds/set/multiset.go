@@ -10,16 +10,16 @@ import (
 
 // MultiSet uses RbTress for internal data structure, and keys can bee repeated.
 type MultiSet struct {
-	tree   *rbtree.RbTree
-	keyCmp comparator.Comparator
-	locker sync.Locker
+	tree     *rbtree.RbTree
+	keyCmp   comparator.Comparator
+	locker   sync.Locker
+	distinct int // the number of distinct elements
 }
 
 // NewMultiSet new a MultiSet
 func NewMultiSet(opts ...Option) *MultiSet {
 	option := Options{
 		keyCmp: defaultKeyComparator,
-		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
@@ -27,7 +27,7 @@ func NewMultiSet(opts ...Option) *MultiSet {
 	return &MultiSet{
 		tree:   rbtree.New(rbtree.WithKeyComparator(option.keyCmp)),
 		keyCmp: option.keyCmp,
-		locker: option.locker,
+		locker: option.newLocker(),
 	}
 }
 
@@ -36,6 +36,9 @@ func (ms *MultiSet) Insert(element interface{}) {
 	ms.locker.Lock()
 	defer ms.locker.Unlock()
 
+	if ms.tree.FindNode(element) == nil {
+		ms.distinct++
+	}
 	ms.tree.Insert(element, Empty)
 }
 
@@ -45,6 +48,9 @@ func (ms *MultiSet) Erase(element interface{}) {
 	defer ms.locker.Unlock()
 
 	node := ms.tree.FindNode(element)
+	if node != nil {
+		ms.distinct--
+	}
 	for node != nil && ms.keyCmp(node.Key(), element) == 0 {
 		nextNode := node.Next()
 		ms.tree.Delete(node)
@@ -52,13 +58,46 @@ func (ms *MultiSet) Erase(element interface{}) {
 	}
 }
 
+// EraseOne erases one occurrence of element in the MultiSet, and returns false if element not exist.
+func (ms *MultiSet) EraseOne(element interface{}) bool {
+	ms.locker.Lock()
+	defer ms.locker.Unlock()
+
+	node := ms.tree.FindNode(element)
+	if node == nil {
+		return false
+	}
+	next := node.Next()
+	if next == nil || ms.keyCmp(next.Key(), element) != 0 {
+		ms.distinct--
+	}
+	ms.tree.Delete(node)
+	return true
+}
+
+// Count returns the number of occurrences of element in the MultiSet
+func (ms *MultiSet) Count(element interface{}) int {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	return ms.count(element)
+}
+
+func (ms *MultiSet) count(element interface{}) int {
+	upper := ms.tree.FindUpperBoundNode(element)
+	if upper == nil {
+		return ms.tree.Size() - ms.tree.Rank(element)
+	}
+	return ms.tree.Rank(upper.Key()) - ms.tree.Rank(element)
+}
+
 // Find returns the iterator related to element in the MultiSet,or an invalid iterator if not exist.
 func (ms *MultiSet) Find(element interface{}) *SetIterator {
 	ms.locker.RLock()
 	defer ms.locker.RUnlock()
 
 	node := ms.tree.FindNode(element)
-	return &SetIterator{node: node}
+	return newSetIterator(node)
 }
 
 //LowerBound returns the first iterator that equal or greater than element in the MultiSet
@@ -67,7 +106,24 @@ func (ms *MultiSet) LowerBound(element interface{}) *SetIterator {
 	defer ms.locker.RUnlock()
 
 	node := ms.tree.FindLowerBoundNode(element)
-	return &SetIterator{node: node}
+	return newSetIterator(node)
+}
+
+// UpperBound returns the first iterator that greater than element in the MultiSet
+func (ms *MultiSet) UpperBound(element interface{}) *SetIterator {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	node := ms.tree.FindUpperBoundNode(element)
+	return newSetIterator(node)
+}
+
+// EqualRange returns the iterators range [first, last) of elements equal to element in the MultiSet
+func (ms *MultiSet) EqualRange(element interface{}) (*SetIterator, *SetIterator) {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	return newSetIterator(ms.tree.FindLowerBoundNode(element)), newSetIterator(ms.tree.FindUpperBoundNode(element))
 }
 
 // Begin returns the iterator with the minimum element in the Set, return nil if empty.
@@ -80,7 +136,7 @@ func (ms *MultiSet) First() *SetIterator {
 	ms.locker.RLock()
 	defer ms.locker.RUnlock()
 
-	return &SetIterator{node: ms.tree.First()}
+	return newSetIterator(ms.tree.First())
 }
 
 //Last returns the iterator with the maximum element in the MultiSet, return nil if empty.
@@ -88,7 +144,7 @@ func (ms *MultiSet) Last() *SetIterator {
 	ms.locker.RLock()
 	defer ms.locker.RUnlock()
 
-	return &SetIterator{node: ms.tree.Last()}
+	return newSetIterator(ms.tree.Last())
 }
 
 // Clear clears the MultiSet
@@ -97,6 +153,7 @@ func (ms *MultiSet) Clear() {
 	defer ms.locker.Unlock()
 
 	ms.tree.Clear()
+	ms.distinct = 0
 }
 
 // Contains returns true if element in the MultiSet. otherwise returns false.
@@ -110,7 +167,7 @@ func (ms *MultiSet) Contains(element interface{}) bool {
 	return false
 }
 
-// Size returns the size of MultiSet
+// Size returns the size of MultiSet, including duplicates
 func (ms *MultiSet) Size() int {
 	ms.locker.RLock()
 	defer ms.locker.RUnlock()
@@ -118,6 +175,14 @@ func (ms *MultiSet) Size() int {
 	return ms.tree.Size()
 }
 
+// DistinctSize returns the number of distinct elements in the MultiSet
+func (ms *MultiSet) DistinctSize() int {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	return ms.distinct
+}
+
 // Traversal traversals elements in MultiSet, it will not stop until to the end or visitor returns false
 func (ms *MultiSet) Traversal(visitor visitor.Visitor) {
 	ms.locker.RLock()
@@ -130,6 +195,23 @@ func (ms *MultiSet) Traversal(visitor visitor.Visitor) {
 	}
 }
 
+// TraversalDistinct traversals distinct elements in MultiSet with their counts,
+// it will not stop until to the end or visitor returns false
+func (ms *MultiSet) TraversalDistinct(visitor visitor.KvVisitor) {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	for node := ms.tree.First(); node != nil; {
+		count := ms.count(node.Key())
+		if !visitor(node.Key(), count) {
+			break
+		}
+		for i := 0; i < count; i++ {
+			node = node.Next()
+		}
+	}
+}
+
 // String returns the set's elements in string format
 func (ms *MultiSet) String() string {
 	str := "["
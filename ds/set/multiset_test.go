@@ -39,3 +39,84 @@ func TestMultiSet(t *testing.T) {
 	iter = mset.Find(3)
 	assert.Equal(t, 3, iter.Value())
 }
+
+func TestMultiSetCount(t *testing.T) {
+	mset := NewMultiSet()
+	assert.Equal(t, 0, mset.Count(1))
+	assert.False(t, mset.EraseOne(1))
+
+	for i := 0; i < 3; i++ {
+		mset.Insert(2)
+		mset.Insert(1)
+	}
+	mset.Insert(4)
+	assert.Equal(t, 7, mset.Size())
+	assert.Equal(t, 3, mset.DistinctSize())
+	assert.Equal(t, 3, mset.Count(1))
+	assert.Equal(t, 3, mset.Count(2))
+	assert.Equal(t, 0, mset.Count(3))
+	assert.Equal(t, 1, mset.Count(4))
+
+	first, last := mset.EqualRange(2)
+	n := 0
+	for iter := first; !iter.Equal(last); iter.Next() {
+		assert.Equal(t, 2, iter.Value())
+		n++
+	}
+	assert.Equal(t, 3, n)
+	first, last = mset.EqualRange(3)
+	assert.True(t, first.Equal(last))
+	assert.Equal(t, 4, mset.UpperBound(2).Value())
+	assert.False(t, mset.UpperBound(4).IsValid())
+
+	assert.True(t, mset.EraseOne(2))
+	assert.True(t, mset.EraseOne(2))
+	assert.Equal(t, 1, mset.Count(2))
+	assert.Equal(t, 3, mset.DistinctSize())
+	assert.True(t, mset.EraseOne(2))
+	assert.False(t, mset.EraseOne(2))
+	assert.Equal(t, 0, mset.Count(2))
+	assert.Equal(t, 2, mset.DistinctSize())
+	assert.Equal(t, 4, mset.Size())
+
+	keys := make([]interface{}, 0)
+	counts := make([]interface{}, 0)
+	mset.TraversalDistinct(func(key, count interface{}) bool {
+		keys = append(keys, key)
+		counts = append(counts, count)
+		return true
+	})
+	assert.Equal(t, []interface{}{1, 4}, keys)
+	assert.Equal(t, []interface{}{3, 1}, counts)
+
+	mset.Erase(1)
+	assert.Equal(t, 1, mset.DistinctSize())
+	mset.Clear()
+	assert.Equal(t, 0, mset.DistinctSize())
+}
+
+func TestMultiSetEqualRange(t *testing.T) {
+	mset := NewMultiSet()
+	first, last := mset.EqualRange(1)
+	assert.False(t, first.IsValid())
+	assert.True(t, first.Equal(last))
+
+	for _, v := range []int{1, 2, 2, 2, 3} {
+		mset.Insert(v)
+	}
+	first, last = mset.EqualRange(2)
+	assert.Equal(t, 2, first.Value())
+	assert.Equal(t, 3, last.Value())
+
+	// the range of the remaining elements is still right after erasing the elements around it
+	mset.Erase(1)
+	mset.Erase(3)
+	first, last = mset.EqualRange(2)
+	n := 0
+	for iter := first; !iter.Equal(last); iter.Next() {
+		assert.Equal(t, 2, iter.Value())
+		n++
+	}
+	assert.Equal(t, 3, n)
+	assert.False(t, last.IsValid())
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/sync"
 	"github.com/liyue201/gostl/utils/visitor"
+	"sort"
 	gosync "sync"
+	"unsafe"
 )
 
 // constants
@@ -21,8 +23,16 @@ var (
 
 // Options holds Set's options
 type Options struct {
-	keyCmp comparator.Comparator
-	locker sync.Locker
+	keyCmp        comparator.Comparator
+	goroutineSafe bool
+}
+
+// newLocker returns a new lock for a goroutine-safe Set, or the fake lock otherwise
+func (option *Options) newLocker() sync.Locker {
+	if option.goroutineSafe {
+		return &gosync.RWMutex{}
+	}
+	return defaultLocker
 }
 
 // Option is a function used to set Options
@@ -40,38 +50,107 @@ func WithKeyComparator(cmp comparator.Comparator) Option {
 // so don't use iterators in multi goroutines
 func WithGoroutineSafe() Option {
 	return func(option *Options) {
-		option.locker = &gosync.RWMutex{}
+		option.goroutineSafe = true
 	}
 }
 
 // Set uses RbTress for internal data structure, and every key can must bee unique.
 type Set struct {
-	tree   *rbtree.RbTree
-	keyCmp comparator.Comparator
-	locker sync.Locker
+	tree    *rbtree.RbTree
+	keyCmp  comparator.Comparator
+	locker  sync.Locker
+	options Options // the options s is created with, used to create Sets like s
 }
 
 // New news a set
 func New(opts ...Option) *Set {
 	option := Options{
 		keyCmp: defaultKeyComparator,
-		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
+	return newWithOptions(option)
+}
+
+func newWithOptions(option Options) *Set {
 	return &Set{
-		tree:   rbtree.New(rbtree.WithKeyComparator(option.keyCmp)),
-		keyCmp: option.keyCmp,
-		locker: option.locker,
+		tree:    rbtree.New(rbtree.WithKeyComparator(option.keyCmp)),
+		keyCmp:  option.keyCmp,
+		locker:  option.newLocker(),
+		options: option,
+	}
+}
+
+// initZero initializes a zero-value Set, e.g. allocated by encoding/gob, with the default options
+func (s *Set) initZero() {
+	if s.tree != nil {
+		return
+	}
+	if s.keyCmp == nil {
+		s.keyCmp = defaultKeyComparator
+	}
+	if s.locker == nil {
+		s.locker = defaultLocker
+	}
+	s.options.keyCmp = s.keyCmp
+	s.tree = rbtree.New(rbtree.WithKeyComparator(s.keyCmp))
+}
+
+// newLike news an empty Set with the same options as s, it has a lock of its own if s is goroutine-safe
+func (s *Set) newLike() *Set {
+	return newWithOptions(s.options)
+}
+
+// FromSlice news a set with the elements in items, the duplicate elements are only inserted once
+func FromSlice(items []interface{}, opts ...Option) *Set {
+	s := New(opts...)
+	sorted := make([]interface{}, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return s.keyCmp(sorted[i], sorted[j]) < 0
+	})
+	keys := make([]interface{}, 0, len(sorted))
+	for _, item := range sorted {
+		if len(keys) > 0 && s.keyCmp(keys[len(keys)-1], item) == 0 {
+			continue
+		}
+		keys = append(keys, item)
+	}
+	values := make([]interface{}, len(keys))
+	for i := range values {
+		values[i] = Empty
 	}
+	s.tree = rbtree.NewFromSorted(keys, values, rbtree.WithKeyComparator(s.keyCmp))
+	return s
 }
 
-// Insert inserts element to the Set
-func (s *Set) Insert(element interface{}) {
+// Collect news a set with the elements yielded by seq, such as the sequence returned by All
+func Collect(seq func(yield func(interface{}) bool), opts ...Option) *Set {
+	s := New(opts...)
+	seq(func(element interface{}) bool {
+		s.insert(element)
+		return true
+	})
+	return s
+}
+
+// Insert inserts elements to the Set
+func (s *Set) Insert(elements ...interface{}) {
+	s.InsertRange(elements)
+}
+
+// InsertRange inserts all elements of items into the Set, holding the write lock only once
+func (s *Set) InsertRange(items []interface{}) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
+	for _, element := range items {
+		s.insert(element)
+	}
+}
+
+func (s *Set) insert(element interface{}) {
 	node := s.tree.FindNode(element)
 	if node != nil {
 		return
@@ -79,15 +158,33 @@ func (s *Set) Insert(element interface{}) {
 	s.tree.Insert(element, Empty)
 }
 
-// Erase erases element in the Set
-func (s *Set) Erase(element interface{}) {
+// Erase erases elements in the Set
+func (s *Set) Erase(elements ...interface{}) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	node := s.tree.FindNode(element)
-	if node != nil {
+	for _, element := range elements {
+		node := s.tree.FindNode(element)
+		if node != nil {
+			s.tree.Delete(node)
+		}
+	}
+}
+
+// EraseRange erases all elements in range [lo, hi) and returns the number of elements erased
+func (s *Set) EraseRange(lo, hi interface{}) int {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	count := 0
+	node := s.tree.FindLowerBoundNode(lo)
+	for node != nil && s.keyCmp(node.Key(), hi) < 0 {
+		next := node.Next()
 		s.tree.Delete(node)
+		node = next
+		count++
 	}
+	return count
 }
 
 // Find returns the iterator related to element in the Set,or an invalid iterator if not exist.
@@ -96,7 +193,7 @@ func (s *Set) Find(element interface{}) *SetIterator {
 	defer s.locker.RUnlock()
 
 	node := s.tree.FindNode(element)
-	return &SetIterator{node: node}
+	return newSetIterator(node)
 }
 
 // LowerBound returns the first iterator that equal or greater than element in the Set
@@ -105,7 +202,16 @@ func (s *Set) LowerBound(element interface{}) *SetIterator {
 	defer s.locker.RUnlock()
 
 	node := s.tree.FindLowerBoundNode(element)
-	return &SetIterator{node: node}
+	return newSetIterator(node)
+}
+
+// UpperBound returns the first iterator that greater than element in the Set
+func (s *Set) UpperBound(element interface{}) *SetIterator {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	node := s.tree.FindUpperBoundNode(element)
+	return newSetIterator(node)
 }
 
 // Begin returns the iterator with the minimum element in the Set, return nil if empty.
@@ -118,7 +224,7 @@ func (s *Set) First() *SetIterator {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
-	return &SetIterator{node: s.tree.First()}
+	return newSetIterator(s.tree.First())
 }
 
 // Last returns the iterator with the maximum element in the Set, return nil if empty.
@@ -126,7 +232,76 @@ func (s *Set) Last() *SetIterator {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
-	return &SetIterator{node: s.tree.Last()}
+	return newSetIterator(s.tree.Last())
+}
+
+// Min returns the minimum element in the Set, ok is false if the Set is empty
+func (s *Set) Min() (interface{}, bool) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return elementOf(s.tree.First())
+}
+
+// Max returns the maximum element in the Set, ok is false if the Set is empty
+func (s *Set) Max() (interface{}, bool) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return elementOf(s.tree.Last())
+}
+
+// PopMin removes the minimum element from the Set and returns it, ok is false if the Set is empty
+func (s *Set) PopMin() (interface{}, bool) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.pop(s.tree.First())
+}
+
+// PopMax removes the maximum element from the Set and returns it, ok is false if the Set is empty
+func (s *Set) PopMax() (interface{}, bool) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.pop(s.tree.Last())
+}
+
+func elementOf(node *rbtree.Node) (interface{}, bool) {
+	if node == nil {
+		return nil, false
+	}
+	return node.Key(), true
+}
+
+func (s *Set) pop(node *rbtree.Node) (interface{}, bool) {
+	element, ok := elementOf(node)
+	if ok {
+		s.tree.Delete(node)
+	}
+	return element, ok
+}
+
+// At returns the iterator with the n-th (0-indexed) smallest element in the Set, or an invalid iterator if n is out of range.
+// It takes O(log n) time.
+func (s *Set) At(n int) *SetIterator {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return newSetIterator(s.tree.Select(n))
+}
+
+// RBegin returns the reverse iterator with the maximum element in the Set, return an invalid iterator if empty.
+func (s *Set) RBegin() *SetReverseIterator {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return newSetReverseIterator(s.tree.Last())
+}
+
+// REnd returns the reverse iterator past the minimum element in the Set, it is always invalid.
+func (s *Set) REnd() *SetReverseIterator {
+	return newSetReverseIterator(nil)
 }
 
 // Clear clears the Set
@@ -137,6 +312,15 @@ func (s *Set) Clear() {
 	s.tree.Clear()
 }
 
+// Reset clears the Set and releases the references of all its nodes in O(n) time, so that the memory of a large Set
+// can be reclaimed promptly even if some iterators of it are still kept. Those iterators must not be used after Reset.
+func (s *Set) Reset() {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.tree.Reset()
+}
+
 // Contains returns true if element in the Set. otherwise returns false.
 func (s *Set) Contains(element interface{}) bool {
 	s.locker.RLock()
@@ -168,6 +352,58 @@ func (s *Set) Traversal(visitor visitor.Visitor) {
 	}
 }
 
+// All returns a sequence of the elements in ascending order, it can be used with range-over-func since go1.23:
+//
+//	for v := range s.All() { ... }
+//
+// The Set is read-locked until the loop ends, so the Set must not be modified in the loop body.
+func (s *Set) All() func(yield func(value interface{}) bool) {
+	return func(yield func(value interface{}) bool) {
+		s.locker.RLock()
+		defer s.locker.RUnlock()
+
+		for node := s.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls fn for every element in the Set in ascending order.
+// Note that the Set is read-locked during the iteration, so fn must not modify the Set.
+func (s *Set) ForEach(fn func(value interface{})) {
+	s.ForEachIf(func(value interface{}) bool {
+		fn(value)
+		return true
+	})
+}
+
+// ForEachIf calls fn for the elements in the Set in ascending order until fn returns false.
+// Note that the Set is read-locked during the iteration, so fn must not modify the Set.
+func (s *Set) ForEachIf(fn func(value interface{}) bool) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	for node := s.tree.First(); node != nil; node = node.Next() {
+		if !fn(node.Key()) {
+			break
+		}
+	}
+}
+
+// ToSlice returns the elements in the Set in ascending order
+func (s *Set) ToSlice() []interface{} {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	items := make([]interface{}, 0, s.tree.Size())
+	for node := s.tree.First(); node != nil; node = node.Next() {
+		items = append(items, node.Key())
+	}
+	return items
+}
+
 // String returns the set's elements in string format
 func (s *Set) String() string {
 	str := "["
@@ -182,13 +418,13 @@ func (s *Set) String() string {
 	return str
 }
 
-// Intersect returns a set with the common elements in s set and the other set
-// Please ensure s set and other set uses the same keyCmp
+// Intersect returns a set with the common elements in s set and the other set.
+// The result uses s's keyCmp and goroutine-safety, please ensure other set uses the same keyCmp, otherwise the result is undefined.
 func (s *Set) Intersect(other *Set) *Set {
-	s.locker.RLock()
-	defer s.locker.RUnlock()
+	unlock := rlockPair(s, other)
+	defer unlock()
 
-	set := New(WithKeyComparator(s.keyCmp))
+	set := s.newLike()
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
@@ -206,13 +442,13 @@ func (s *Set) Intersect(other *Set) *Set {
 	return set
 }
 
-// Union returns  a set with the all elements in s set and the other set
-// Please ensure s set and other set uses the same keyCmp
+// Union returns a set with the all elements in s set and the other set.
+// The result uses s's keyCmp and goroutine-safety, please ensure other set uses the same keyCmp, otherwise the result is undefined.
 func (s *Set) Union(other *Set) *Set {
-	s.locker.RLock()
-	defer s.locker.RUnlock()
+	unlock := rlockPair(s, other)
+	defer unlock()
 
-	set := New(WithKeyComparator(s.keyCmp))
+	set := s.newLike()
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
@@ -238,13 +474,13 @@ func (s *Set) Union(other *Set) *Set {
 	return set
 }
 
-// Diff returns a set with the elements in s set but not in the other set
-// Please ensure s set and other set uses the same keyCmp
-func (s *Set) Diff(other *Set) *Set {
-	s.locker.RLock()
-	defer s.locker.RUnlock()
+// Difference returns a set with the elements in s set but not in the other set.
+// The result uses s's keyCmp and goroutine-safety, please ensure other set uses the same keyCmp, otherwise the result is undefined.
+func (s *Set) Difference(other *Set) *Set {
+	unlock := rlockPair(s, other)
+	defer unlock()
 
-	set := New(WithKeyComparator(s.keyCmp))
+	set := s.newLike()
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
@@ -264,3 +500,162 @@ func (s *Set) Diff(other *Set) *Set {
 	}
 	return set
 }
+
+// SymmetricDifference returns a set with the elements in either s set or the other set but not in both.
+// The result uses s's keyCmp and goroutine-safety, please ensure other set uses the same keyCmp, otherwise the result is undefined.
+func (s *Set) SymmetricDifference(other *Set) *Set {
+	unlock := rlockPair(s, other)
+	defer unlock()
+
+	set := s.newLike()
+	sIter := s.tree.IterFirst()
+	otherIter := other.tree.IterFirst()
+	for sIter.IsValid() && otherIter.IsValid() {
+		cmp := s.keyCmp(sIter.Key(), otherIter.Key())
+		if cmp == 0 {
+			sIter.Next()
+			otherIter.Next()
+		} else if cmp < 0 {
+			set.tree.Insert(sIter.Key(), Empty)
+			sIter.Next()
+		} else {
+			set.tree.Insert(otherIter.Key(), Empty)
+			otherIter.Next()
+		}
+	}
+	for ; sIter.IsValid(); sIter.Next() {
+		set.tree.Insert(sIter.Key(), Empty)
+	}
+	for ; otherIter.IsValid(); otherIter.Next() {
+		set.tree.Insert(otherIter.Key(), Empty)
+	}
+	return set
+}
+
+// Diff is the same as Difference
+func (s *Set) Diff(other *Set) *Set {
+	return s.Difference(other)
+}
+
+// IsSubsetOf returns true if all elements in s set are also in the other set, an empty set is a subset of any set.
+// Please ensure s set and other set uses the same keyCmp
+func (s *Set) IsSubsetOf(other *Set) bool {
+	unlock := rlockPair(s, other)
+	defer unlock()
+
+	if s.tree.Size() > other.tree.Size() {
+		return false
+	}
+	sIter := s.tree.IterFirst()
+	otherIter := other.tree.IterFirst()
+	for sIter.IsValid() && otherIter.IsValid() {
+		cmp := s.keyCmp(sIter.Key(), otherIter.Key())
+		if cmp == 0 {
+			sIter.Next()
+			otherIter.Next()
+		} else if cmp < 0 {
+			return false
+		} else {
+			otherIter.Next()
+		}
+	}
+	return !sIter.IsValid()
+}
+
+// Equal returns true if s set and the other set have the same elements
+// Please ensure s set and other set uses the same keyCmp
+func (s *Set) Equal(other *Set) bool {
+	unlock := rlockPair(s, other)
+	defer unlock()
+
+	if s.tree.Size() != other.tree.Size() {
+		return false
+	}
+	sIter := s.tree.IterFirst()
+	otherIter := other.tree.IterFirst()
+	for ; sIter.IsValid() && otherIter.IsValid(); sIter.Next() {
+		if s.keyCmp(sIter.Key(), otherIter.Key()) != 0 {
+			return false
+		}
+		otherIter.Next()
+	}
+	return true
+}
+
+// UnionWith inserts all elements of other into s
+func (s *Set) UnionWith(other *Set) {
+	if s == other {
+		return
+	}
+	defer lockForUpdate(s, other)()
+
+	for node := other.tree.First(); node != nil; node = node.Next() {
+		s.insert(node.Key())
+	}
+}
+
+// IntersectWith erases the elements of s which are not in other
+func (s *Set) IntersectWith(other *Set) {
+	if s == other {
+		return
+	}
+	defer lockForUpdate(s, other)()
+
+	for node := s.tree.First(); node != nil; {
+		next := node.Next()
+		if other.tree.FindNode(node.Key()) == nil {
+			s.tree.Delete(node)
+		}
+		node = next
+	}
+}
+
+// DifferenceWith erases the elements of s which are in other
+func (s *Set) DifferenceWith(other *Set) {
+	if s == other {
+		s.Clear()
+		return
+	}
+	defer lockForUpdate(s, other)()
+
+	for node := other.tree.First(); node != nil; node = node.Next() {
+		if n := s.tree.FindNode(node.Key()); n != nil {
+			s.tree.Delete(n)
+		}
+	}
+}
+
+// lockForUpdate write-locks s and read-locks other in the order of their addresses, s and other must be different.
+// It returns a function to unlock them.
+func lockForUpdate(s, other *Set) func() {
+	if uintptr(unsafe.Pointer(s)) < uintptr(unsafe.Pointer(other)) {
+		s.locker.Lock()
+		other.locker.RLock()
+	} else {
+		other.locker.RLock()
+		s.locker.Lock()
+	}
+	return func() {
+		other.locker.RUnlock()
+		s.locker.Unlock()
+	}
+}
+
+// rlockPair read-locks s and other in the order of their addresses, so that goroutines locking
+// the same pair of sets in opposite order can't deadlock. It returns a function to unlock them.
+func rlockPair(s, other *Set) func() {
+	if s == other {
+		s.locker.RLock()
+		return s.locker.RUnlock
+	}
+	first, second := s, other
+	if uintptr(unsafe.Pointer(first)) > uintptr(unsafe.Pointer(second)) {
+		first, second = second, first
+	}
+	first.locker.RLock()
+	second.locker.RLock()
+	return func() {
+		second.locker.RUnlock()
+		first.locker.RUnlock()
+	}
+}
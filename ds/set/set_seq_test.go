@@ -0,0 +1,50 @@
+//go:build go1.23
+// +build go1.23
+
+package set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetAll(t *testing.T) {
+	s := FromSlice([]interface{}{5, 3, 1, 4, 2}, WithGoroutineSafe())
+
+	var values []interface{}
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, values)
+
+	values = nil
+	for v := range s.All() {
+		if v.(int) > 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []interface{}{1, 2}, values)
+
+	// the lock is released after break
+	s.Insert(6)
+	assert.Equal(t, 6, s.Size())
+}
+
+func TestCollect(t *testing.T) {
+	items := []interface{}{"b", "a", "c", "a"}
+	seq := func(yield func(interface{}) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+	s := Collect(seq)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, s.ToSlice())
+
+	other := Collect(s.All(), WithKeyComparator(func(a, b interface{}) int {
+		return -s.keyCmp(a, b)
+	}))
+	assert.Equal(t, []interface{}{"c", "b", "a"}, other.ToSlice())
+}
@@ -79,3 +79,363 @@ func TestSetIterator(t *testing.T) {
 	s.Clear()
 	assert.Equal(t, 0, s.Size())
 }
+
+func TestSetReset(t *testing.T) {
+	s := New()
+	for i := 0; i < 100; i++ {
+		s.Insert(i)
+	}
+	iter := s.Find(50)
+	s.Reset()
+	assert.Equal(t, 0, s.Size())
+	assert.False(t, s.Contains(50))
+	// the kept iterator no longer references the data of the Set
+	assert.Nil(t, iter.cur.Node().Key())
+	assert.Nil(t, iter.cur.Node().Next())
+
+	s.Insert(1)
+	assert.True(t, s.Contains(1))
+}
+
+func TestSetAlgebra(t *testing.T) {
+	empty := New()
+	s := New()
+	for i := 1; i <= 5; i++ {
+		s.Insert(i)
+	}
+
+	assert.Equal(t, "[]", empty.Union(empty).String())
+	assert.Equal(t, "[1 2 3 4 5]", empty.Union(s).String())
+	assert.Equal(t, "[1 2 3 4 5]", s.Union(empty).String())
+	assert.Equal(t, "[]", s.Intersect(empty).String())
+	assert.Equal(t, "[]", empty.Intersect(s).String())
+	assert.Equal(t, "[1 2 3 4 5]", s.Difference(empty).String())
+	assert.Equal(t, "[]", empty.Difference(s).String())
+	assert.Equal(t, "[]", s.Difference(s).String())
+	assert.Equal(t, "[1 2 3 4 5]", s.Intersect(s).String())
+
+	desc := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	other := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 1; i <= 5; i++ {
+		desc.Insert(i)
+		other.Insert(i + 2)
+	}
+	assert.Equal(t, "[7 6 5 4 3 2 1]", desc.Union(other).String())
+	assert.Equal(t, "[5 4 3]", desc.Intersect(other).String())
+	assert.Equal(t, "[2 1]", desc.Difference(other).String())
+	assert.Equal(t, "[7 6]", other.Difference(desc).String())
+
+	assert.Equal(t, "[7 6 2 1]", desc.SymmetricDifference(other).String())
+
+	// the results have the options of the receiver and a lock of their own
+	for _, op := range []func(s, other *Set) *Set{(*Set).Union, (*Set).Intersect, (*Set).Difference, (*Set).SymmetricDifference} {
+		result := op(desc, other)
+		assert.True(t, result.options.goroutineSafe)
+		assert.True(t, result.locker != desc.locker)
+		assert.Equal(t, defaultLocker, op(other, desc).locker)
+	}
+}
+
+func TestSetSubsetEqual(t *testing.T) {
+	empty := New()
+	s1 := New()
+	s2 := New()
+	for i := 1; i <= 5; i++ {
+		s1.Insert(i)
+		s2.Insert(i * 2)
+	}
+	sub := New(WithGoroutineSafe())
+	sub.Insert(2)
+	sub.Insert(4)
+
+	assert.True(t, empty.IsSubsetOf(empty))
+	assert.True(t, empty.IsSubsetOf(s1))
+	assert.False(t, s1.IsSubsetOf(empty))
+	assert.True(t, sub.IsSubsetOf(s1))
+	assert.True(t, sub.IsSubsetOf(s2))
+	assert.False(t, s1.IsSubsetOf(s2))
+	assert.True(t, s1.IsSubsetOf(s1))
+	sub.Insert(7)
+	assert.False(t, sub.IsSubsetOf(s1))
+
+	assert.True(t, empty.Equal(New()))
+	assert.False(t, empty.Equal(s1))
+	assert.False(t, s1.Equal(empty))
+	assert.True(t, s1.Equal(s1))
+	assert.False(t, s1.Equal(s2))
+	assert.False(t, s2.Equal(s1))
+
+	s3 := New()
+	for i := 5; i >= 1; i-- {
+		s3.Insert(i)
+	}
+	assert.True(t, s1.Equal(s3))
+	assert.True(t, s3.Equal(s1))
+}
+
+func TestSetSlice(t *testing.T) {
+	s := FromSlice([]interface{}{})
+	assert.Equal(t, 0, s.Size())
+	assert.Equal(t, []interface{}{}, s.ToSlice())
+
+	items := []interface{}{5, 3, 1, 3, 5, 2, 4, 1}
+	s = FromSlice(items)
+	assert.Equal(t, 5, s.Size())
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, s.ToSlice())
+	assert.Equal(t, []interface{}{5, 3, 1, 3, 5, 2, 4, 1}, items)
+	s.Insert(0)
+	s.Erase(3)
+	assert.Equal(t, []interface{}{0, 1, 2, 4, 5}, s.ToSlice())
+
+	s = FromSlice(items, WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	assert.Equal(t, []interface{}{5, 4, 3, 2, 1}, s.ToSlice())
+	assert.True(t, s.Contains(4))
+	assert.False(t, s.Contains(6))
+}
+
+func TestSetSymmetricDifference(t *testing.T) {
+	s1 := FromSlice([]interface{}{1, 2, 3})
+	s2 := FromSlice([]interface{}{4, 5})
+	assert.Equal(t, "[1 2 3 4 5]", s1.SymmetricDifference(s2).String())
+	assert.Equal(t, "[1 2 3 4 5]", s2.SymmetricDifference(s1).String())
+
+	assert.Equal(t, "[]", s1.SymmetricDifference(s1).String())
+	assert.Equal(t, "[]", s1.SymmetricDifference(FromSlice([]interface{}{3, 2, 1})).String())
+	assert.Equal(t, "[1 2 3]", s1.SymmetricDifference(New()).String())
+
+	s3 := FromSlice([]interface{}{2, 3, 4, 6})
+	result := s1.SymmetricDifference(s3)
+	assert.Equal(t, "[1 4 6]", result.String())
+
+	result.Insert(10)
+	assert.Equal(t, "[1 2 3]", s1.String())
+	assert.Equal(t, "[2 3 4 6]", s3.String())
+}
+
+func TestSetReverseComparator(t *testing.T) {
+	s := New(WithKeyComparator(comparator.Reverse(comparator.BuiltinTypeComparator)))
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		s.Insert(v)
+	}
+	assert.Equal(t, 7, s.Size())
+	assert.Equal(t, []interface{}{9, 6, 5, 4, 3, 2, 1}, s.ToSlice())
+	assert.True(t, s.Contains(1))
+	assert.Equal(t, 4, s.LowerBound(4).Value())
+	assert.Equal(t, 3, s.Find(4).Next().(*SetIterator).Value())
+}
+
+func TestSetBoundsAndReverse(t *testing.T) {
+	s := New()
+	assert.False(t, s.UpperBound(1).IsValid())
+	assert.False(t, s.RBegin().IsValid())
+	assert.True(t, s.RBegin().Equal(s.REnd()))
+
+	for i := 0; i <= 10; i += 2 {
+		s.Insert(i)
+	}
+	assert.Equal(t, 4, s.LowerBound(4).Value())
+	assert.Equal(t, 6, s.UpperBound(4).Value())
+	assert.Equal(t, 6, s.UpperBound(5).Value())
+	assert.True(t, s.UpperBound(-1).Equal(s.Begin()))
+	assert.False(t, s.UpperBound(10).IsValid())
+
+	var values []interface{}
+	for iter := s.UpperBound(3); !iter.Equal(s.LowerBound(9)); iter.Next() {
+		values = append(values, iter.Value())
+	}
+	assert.Equal(t, []interface{}{4, 6, 8}, values)
+
+	values = nil
+	for iter := s.RBegin(); !iter.Equal(s.REnd()); iter.Next() {
+		values = append(values, iter.Value())
+	}
+	assert.Equal(t, []interface{}{10, 8, 6, 4, 2, 0}, values)
+
+	iter := s.RBegin()
+	iter.Next()
+	assert.Equal(t, 8, iter.Value())
+	iter.Prev()
+	assert.Equal(t, 10, iter.Value())
+	assert.True(t, iter.Equal(iter.Clone()))
+}
+
+func TestSetInsertRange(t *testing.T) {
+	s := New(WithGoroutineSafe())
+	s.Insert()
+	assert.Equal(t, 0, s.Size())
+	s.Insert(3, 1, 2, 1)
+	assert.Equal(t, []interface{}{1, 2, 3}, s.ToSlice())
+	s.InsertRange([]interface{}{5, 4, 3})
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, s.ToSlice())
+	s.InsertRange(nil)
+	assert.Equal(t, 5, s.Size())
+}
+
+func benchmarkSetItems(n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func BenchmarkSetInsertPerElement(b *testing.B) {
+	items := benchmarkSetItems(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := New(WithGoroutineSafe())
+		for _, item := range items {
+			s.Insert(item)
+		}
+	}
+}
+
+func BenchmarkSetInsertRange(b *testing.B) {
+	items := benchmarkSetItems(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := New(WithGoroutineSafe())
+		s.InsertRange(items)
+	}
+}
+
+func TestSetAt(t *testing.T) {
+	s := New()
+	assert.False(t, s.At(0).IsValid())
+
+	for i := 100; i > 0; i-- {
+		s.Insert(i * 2)
+	}
+	assert.Equal(t, 2, s.At(0).Value())
+	assert.Equal(t, 200, s.At(s.Size()-1).Value())
+	assert.Equal(t, 100, s.At(49).Value())
+	assert.False(t, s.At(-1).IsValid())
+	assert.False(t, s.At(s.Size()).IsValid())
+
+	// At(Size()-1) is where RBegin starts, and walking from Begin reaches it
+	assert.Equal(t, s.RBegin().Value(), s.At(s.Size()-1).Value())
+	iter := s.Begin()
+	for i := 0; i < s.Size()-1; i++ {
+		assert.Equal(t, s.At(i).Value(), iter.Value())
+		iter.Next()
+	}
+	assert.True(t, iter.Equal(s.At(s.Size()-1)))
+	assert.True(t, s.At(3).Equal(s.Find(8)))
+}
+
+func TestSetInPlaceOperations(t *testing.T) {
+	newSet := func(items ...interface{}) *Set {
+		s := New(WithGoroutineSafe())
+		s.Insert(items...)
+		return s
+	}
+	a := []interface{}{1, 2, 3, 4, 5, 8}
+	b := []interface{}{4, 5, 6, 7, 8, 9}
+
+	s := newSet(a...)
+	s.UnionWith(newSet(b...))
+	assert.Equal(t, newSet(a...).Union(newSet(b...)).ToSlice(), s.ToSlice())
+
+	s = newSet(a...)
+	s.IntersectWith(newSet(b...))
+	assert.Equal(t, newSet(a...).Intersect(newSet(b...)).ToSlice(), s.ToSlice())
+	assert.Equal(t, []interface{}{4, 5, 8}, s.ToSlice())
+
+	s = newSet(a...)
+	s.DifferenceWith(newSet(b...))
+	assert.Equal(t, newSet(a...).Difference(newSet(b...)).ToSlice(), s.ToSlice())
+	assert.Equal(t, []interface{}{1, 2, 3}, s.ToSlice())
+
+	s.IntersectWith(New())
+	assert.Equal(t, 0, s.Size())
+	s.UnionWith(New())
+	assert.Equal(t, 0, s.Size())
+
+	// with itself
+	s = newSet(a...)
+	s.UnionWith(s)
+	s.IntersectWith(s)
+	assert.Equal(t, a, s.ToSlice())
+	s.DifferenceWith(s)
+	assert.Equal(t, 0, s.Size())
+}
+
+func TestSetEraseRange(t *testing.T) {
+	s := New()
+	s.Erase()
+	assert.Equal(t, 0, s.EraseRange(0, 10))
+
+	for i := 0; i < 20; i++ {
+		s.Insert(i)
+	}
+	s.Erase(3, 17, 8, 100)
+	assert.Equal(t, 17, s.Size())
+	assert.False(t, s.Contains(3))
+	assert.False(t, s.Contains(8))
+	assert.False(t, s.Contains(17))
+
+	assert.Equal(t, 0, s.EraseRange(5, 5))
+	assert.Equal(t, 7, s.EraseRange(5, 13)) // 5 6 7 9 10 11 12, 8 is already erased
+	assert.Equal(t, []interface{}{0, 1, 2, 4, 13, 14, 15, 16, 18, 19}, s.ToSlice())
+	assert.Equal(t, 3, s.EraseRange(16, 100))
+	assert.Equal(t, []interface{}{0, 1, 2, 4, 13, 14, 15}, s.ToSlice())
+}
+
+func TestSetMinMaxPop(t *testing.T) {
+	s := New(WithGoroutineSafe())
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+	_, ok = s.PopMin()
+	assert.False(t, ok)
+	_, ok = s.PopMax()
+	assert.False(t, ok)
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		s.Insert(v)
+	}
+	min, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, min)
+	max, ok := s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, max)
+	assert.Equal(t, 6, s.Size())
+
+	max, _ = s.PopMax()
+	assert.Equal(t, 9, max)
+
+	var drained []interface{}
+	for {
+		v, ok := s.PopMin()
+		if !ok {
+			break
+		}
+		drained = append(drained, v)
+	}
+	assert.Equal(t, []interface{}{1, 2, 3, 5, 8}, drained)
+	assert.Equal(t, 0, s.Size())
+}
+
+func TestSetForEach(t *testing.T) {
+	s := New(WithGoroutineSafe())
+	s.Insert(3, 1, 4, 5, 9, 2, 6)
+
+	var values []interface{}
+	s.ForEach(func(value interface{}) {
+		values = append(values, value)
+	})
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6, 9}, values)
+
+	values = nil
+	s.ForEachIf(func(value interface{}) bool {
+		values = append(values, value)
+		return value.(int) < 4
+	})
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, values)
+
+	New().ForEach(func(value interface{}) {
+		t.Fatal("unexpected element")
+	})
+}
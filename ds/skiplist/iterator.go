@@ -0,0 +1,53 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+// SkiplistIterator is an iterator for Skiplist
+type SkiplistIterator struct {
+	element *Element
+}
+
+// IsValid returns whether iter is valid
+func (iter *SkiplistIterator) IsValid() bool {
+	return iter.element != nil
+}
+
+// Next returns the next iterator
+func (iter *SkiplistIterator) Next() iterator.ConstIterator {
+	if iter.IsValid() {
+		iter.element = iter.element.next[0]
+	}
+	return iter
+}
+
+// Key returns the key of iter
+func (iter *SkiplistIterator) Key() interface{} {
+	return iter.element.key
+}
+
+// Value returns the value of iter
+func (iter *SkiplistIterator) Value() interface{} {
+	return iter.element.value
+}
+
+// SetValue sets the value of iter
+func (iter *SkiplistIterator) SetValue(val interface{}) error {
+	iter.element.value = val
+	return nil
+}
+
+// Clone clones iter to a new SkiplistIterator
+func (iter *SkiplistIterator) Clone() iterator.ConstIterator {
+	return &SkiplistIterator{iter.element}
+}
+
+// Equal returns whether iter is equal to other
+func (iter *SkiplistIterator) Equal(other iterator.ConstIterator) bool {
+	otherIter, ok := other.(*SkiplistIterator)
+	if !ok {
+		return false
+	}
+	return otherIter.element == iter.element
+}
@@ -163,6 +163,59 @@ func (sl *Skiplist) Remove(key interface{}) bool {
 	return true
 }
 
+// Erase erases the element associated with the key passed and returns true if exist,or false if not exist.
+// It is the same as Remove
+func (sl *Skiplist) Erase(key interface{}) bool {
+	return sl.Remove(key)
+}
+
+// Contains returns true if key in the Skiplist, otherwise returns false
+func (sl *Skiplist) Contains(key interface{}) bool {
+	return sl.Find(key).IsValid()
+}
+
+// Find returns the iterator related to key in the Skiplist, or an invalid iterator if not exist
+func (sl *Skiplist) Find(key interface{}) *SkiplistIterator {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	e := sl.findLowerBound(key)
+	if e != nil && sl.keyCmp(e.key, key) != 0 {
+		e = nil
+	}
+	return &SkiplistIterator{element: e}
+}
+
+// LowerBound returns the first iterator that equal or greater than key in the Skiplist
+func (sl *Skiplist) LowerBound(key interface{}) *SkiplistIterator {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	return &SkiplistIterator{element: sl.findLowerBound(key)}
+}
+
+// Begin returns the iterator with the minimum key in the Skiplist, or an invalid iterator if empty
+func (sl *Skiplist) Begin() *SkiplistIterator {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	return &SkiplistIterator{element: sl.head.next[0]}
+}
+
+// Clear clears the Skiplist
+func (sl *Skiplist) Clear() {
+	sl.locker.Lock()
+	defer sl.locker.Unlock()
+
+	sl.head.next = make([]*Element, sl.maxLevel)
+	sl.len = 0
+}
+
+// Size returns the number of elements in the Skiplist. It is the same as Len
+func (sl *Skiplist) Size() int {
+	return sl.Len()
+}
+
 // Len returns the number of elements in the skiplist
 func (sl *Skiplist) Len() int {
 	sl.locker.RLock()
@@ -183,6 +236,21 @@ func (sl *Skiplist) randomLevel() int {
 	return level
 }
 
+// findLowerBound returns the first element that equal or greater than key, it doesn't modify prevNodesCache
+// so that it can be called with only the read lock held
+func (sl *Skiplist) findLowerBound(key interface{}) *Element {
+	prev := &sl.head
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for next := prev.next[i]; next != nil; next = next.next[i] {
+			if sl.keyCmp(next.key, key) >= 0 {
+				break
+			}
+			prev = &next.Node
+		}
+	}
+	return prev.next[0]
+}
+
 func (sl *Skiplist) findPrevNodes(key interface{}) []*Node {
 	prevs := sl.prevNodesCache
 	prev := &sl.head
@@ -4,6 +4,7 @@ import (
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -67,3 +68,57 @@ func TestSkiplist_Traversal(t *testing.T) {
 		return true
 	})
 }
+
+func TestSkiplistAgainstSortedReference(t *testing.T) {
+	list := New(WithMaxLevel(8), WithKeyComparator(comparator.IntComparator))
+	m := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := rand.Intn(500)
+		if rand.Intn(3) == 0 {
+			_, ok := m[key]
+			assert.Equal(t, ok, list.Erase(key))
+			delete(m, key)
+		} else {
+			list.Insert(key, i)
+			m[key] = i
+		}
+	}
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	assert.Equal(t, len(keys), list.Size())
+	i := 0
+	for iter := list.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, keys[i], iter.Key())
+		assert.Equal(t, m[keys[i]], iter.Value())
+		i++
+	}
+	assert.Equal(t, len(keys), i)
+
+	for key := -1; key <= 501; key++ {
+		pos := sort.SearchInts(keys, key)
+		iter := list.LowerBound(key)
+		if pos == len(keys) {
+			assert.False(t, iter.IsValid())
+		} else {
+			assert.Equal(t, keys[pos], iter.Key())
+		}
+		_, ok := m[key]
+		assert.Equal(t, ok, list.Contains(key))
+		assert.Equal(t, ok, list.Find(key).IsValid())
+	}
+
+	iter := list.Begin()
+	iter.SetValue(-1)
+	assert.Equal(t, -1, list.Get(iter.Key()))
+	assert.True(t, iter.Equal(iter.Clone()))
+
+	list.Clear()
+	assert.Equal(t, 0, list.Size())
+	assert.False(t, list.Begin().IsValid())
+	list.Insert(1, 1)
+	assert.Equal(t, 1, list.Get(1))
+}
@@ -0,0 +1,190 @@
+package sortedlog
+
+import (
+	"errors"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+	"sort"
+	gosync "sync"
+)
+
+var (
+	defaultKeyComparator = comparator.BuiltinTypeComparator
+	defaultLocker        sync.FakeLocker
+)
+
+// ErrOutOfOrder is returned by Append when the key is less than the last key in the SortedLog
+var ErrOutOfOrder = errors.New("key is less than the last key")
+
+// Options holds SortedLog's options
+type Options struct {
+	keyCmp   comparator.Comparator
+	capacity int
+	locker   sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithKeyComparator sets the comparator used to order the keys
+func WithKeyComparator(cmp comparator.Comparator) Option {
+	return func(option *Options) {
+		option.keyCmp = cmp
+	}
+}
+
+// WithCapacity sets the initial capacity of SortedLog
+func WithCapacity(capacity int) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// WithGoroutineSafe sets SortedLog goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+}
+
+// SortedLog is an append-only log of key-values whose keys are non-decreasing, e.g. timestamps, and keys can be repeated.
+// The entries are stored in a slice, so it is more compact and cache-friendly than a tree,
+// Append takes amortized O(1) time and lookups use binary search and take O(log n) time.
+type SortedLog struct {
+	entries []entry
+	keyCmp  comparator.Comparator
+	locker  sync.Locker
+}
+
+// New creates a new SortedLog
+func New(opts ...Option) *SortedLog {
+	option := Options{
+		keyCmp: defaultKeyComparator,
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &SortedLog{
+		entries: make([]entry, 0, option.capacity),
+		keyCmp:  option.keyCmp,
+		locker:  option.locker,
+	}
+}
+
+// Append appends key-value to the end of the SortedLog, it returns ErrOutOfOrder and does nothing if key is less than
+// the last key in the SortedLog
+func (l *SortedLog) Append(key, value interface{}) error {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if n := len(l.entries); n > 0 && l.keyCmp(key, l.entries[n-1].key) < 0 {
+		return ErrOutOfOrder
+	}
+	l.entries = append(l.entries, entry{key: key, value: value})
+	return nil
+}
+
+// Find returns the value of the first entry with key and true if found, or nil and false if not found
+func (l *SortedLog) Find(key interface{}) (interface{}, bool) {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	pos := l.lowerBound(key)
+	if pos < len(l.entries) && l.keyCmp(l.entries[pos].key, key) == 0 {
+		return l.entries[pos].value, true
+	}
+	return nil, false
+}
+
+// LowerBound returns the position of the first entry whose key is equal or greater than key, or Size() if not exist
+func (l *SortedLog) LowerBound(key interface{}) int {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	return l.lowerBound(key)
+}
+
+func (l *SortedLog) lowerBound(key interface{}) int {
+	return sort.Search(len(l.entries), func(i int) bool {
+		return l.keyCmp(l.entries[i].key, key) >= 0
+	})
+}
+
+// UpperBound returns the position of the first entry whose key is greater than key, or Size() if not exist
+func (l *SortedLog) UpperBound(key interface{}) int {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	return l.upperBound(key)
+}
+
+func (l *SortedLog) upperBound(key interface{}) int {
+	return sort.Search(len(l.entries), func(i int) bool {
+		return l.keyCmp(l.entries[i].key, key) > 0
+	})
+}
+
+// At returns the key-value at position and true, or nil, nil and false if position is out of range
+func (l *SortedLog) At(position int) (key, value interface{}, ok bool) {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	if position < 0 || position >= len(l.entries) {
+		return nil, nil, false
+	}
+	e := l.entries[position]
+	return e.key, e.value, true
+}
+
+// Range calls visitor for each entry with key in range [lo, hi) in order, until visitor returns false.
+// A nil lo or hi means the range is unbounded on that side.
+func (l *SortedLog) Range(lo, hi interface{}, visitor visitor.KvVisitor) {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	begin, end := 0, len(l.entries)
+	if lo != nil {
+		begin = l.lowerBound(lo)
+	}
+	if hi != nil {
+		end = l.lowerBound(hi)
+	}
+	for i := begin; i < end; i++ {
+		if !visitor(l.entries[i].key, l.entries[i].value) {
+			return
+		}
+	}
+}
+
+// Traversal traversals all the entries in order, it will not stop until to the end or visitor returns false
+func (l *SortedLog) Traversal(visitor visitor.KvVisitor) {
+	l.Range(nil, nil, visitor)
+}
+
+// Size returns the number of entries in the SortedLog
+func (l *SortedLog) Size() int {
+	l.locker.RLock()
+	defer l.locker.RUnlock()
+
+	return len(l.entries)
+}
+
+// Empty returns true if the SortedLog contains no entries
+func (l *SortedLog) Empty() bool {
+	return l.Size() == 0
+}
+
+// Clear removes all the entries from the SortedLog
+func (l *SortedLog) Clear() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.entries = make([]entry, 0, cap(l.entries))
+}
@@ -0,0 +1,88 @@
+package sortedlog
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func collect(l *SortedLog, lo, hi interface{}) []interface{} {
+	var values []interface{}
+	l.Range(lo, hi, func(key, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+func TestSortedLog(t *testing.T) {
+	l := New(WithCapacity(8))
+	assert.True(t, l.Empty())
+	_, ok := l.Find(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, l.LowerBound(1))
+
+	assert.Nil(t, l.Append(1, "a"))
+	assert.Nil(t, l.Append(3, "b"))
+	assert.Nil(t, l.Append(3, "c"))
+	assert.Nil(t, l.Append(3, "d"))
+	assert.Nil(t, l.Append(7, "e"))
+	assert.Equal(t, 5, l.Size())
+
+	// duplicate keys
+	v, ok := l.Find(3)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 1, l.LowerBound(3))
+	assert.Equal(t, 4, l.UpperBound(3))
+	assert.Equal(t, []interface{}{"b", "c", "d"}, collect(l, 3, 4))
+
+	// boundaries
+	_, ok = l.Find(5)
+	assert.False(t, ok)
+	assert.Equal(t, 0, l.LowerBound(0))
+	assert.Equal(t, 0, l.LowerBound(1))
+	assert.Equal(t, 1, l.UpperBound(1))
+	assert.Equal(t, 4, l.LowerBound(5))
+	assert.Equal(t, 4, l.LowerBound(7))
+	assert.Equal(t, 5, l.UpperBound(7))
+	assert.Equal(t, 5, l.LowerBound(8))
+	assert.Equal(t, []interface{}{"a", "b", "c", "d"}, collect(l, nil, 7))
+	assert.Equal(t, []interface{}{"e"}, collect(l, 4, nil))
+	assert.Nil(t, collect(l, 8, nil))
+	assert.Nil(t, collect(l, 5, 2))
+
+	key, value, ok := l.At(4)
+	assert.True(t, ok)
+	assert.Equal(t, 7, key)
+	assert.Equal(t, "e", value)
+	_, _, ok = l.At(5)
+	assert.False(t, ok)
+
+	var keys []interface{}
+	l.Traversal(func(key, value interface{}) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	assert.Equal(t, []interface{}{1, 3, 3}, keys)
+
+	l.Clear()
+	assert.True(t, l.Empty())
+	assert.Nil(t, l.Append(0, "f"))
+}
+
+func TestSortedLogOutOfOrder(t *testing.T) {
+	l := New(WithGoroutineSafe())
+	assert.Nil(t, l.Append(5, "a"))
+	assert.Nil(t, l.Append(5, "b"))
+	assert.Equal(t, ErrOutOfOrder, l.Append(4, "c"))
+	assert.Equal(t, 2, l.Size())
+	assert.Nil(t, l.Append(6, "d"))
+
+	l = New(WithKeyComparator(comparator.Reverse(comparator.BuiltinTypeComparator)))
+	assert.Nil(t, l.Append(5, "a"))
+	assert.Nil(t, l.Append(2, "b"))
+	assert.Equal(t, ErrOutOfOrder, l.Append(3, "c"))
+	v, _ := l.Find(2)
+	assert.Equal(t, "b", v)
+}
@@ -0,0 +1,259 @@
+package sortedvector
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+	"sort"
+	gosync "sync"
+)
+
+var (
+	defaultKeyComparator = comparator.BuiltinTypeComparator
+	defaultLocker        sync.FakeLocker
+)
+
+// Options holds SortedVector's options
+type Options struct {
+	keyCmp       comparator.Comparator
+	capacity     int
+	growthFactor float64
+	locker       sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithKeyComparator sets the comparator used to order the elements
+func WithKeyComparator(cmp comparator.Comparator) Option {
+	return func(option *Options) {
+		option.keyCmp = cmp
+	}
+}
+
+// WithCapacity sets the initial capacity of SortedVector
+func WithCapacity(capacity int) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// WithGrowthFactor sets the factor by which the capacity of SortedVector grows when it is full,
+// by default the growth of the builtin append is used. A factor not greater than 1 is ignored.
+func WithGrowthFactor(factor float64) Option {
+	return func(option *Options) {
+		option.growthFactor = factor
+	}
+}
+
+// WithGoroutineSafe sets SortedVector goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// SortedVector keeps its elements in ascending order in a slice, and elements can be repeated.
+// Lookups use binary search and take O(log n) time, with better cache locality than a tree,
+// but Insert and Erase take O(n) time because the elements after the position have to be shifted.
+// So it suits read-mostly workloads.
+type SortedVector struct {
+	data         []interface{}
+	keyCmp       comparator.Comparator
+	growthFactor float64
+	locker       sync.Locker
+}
+
+// New news a SortedVector
+func New(opts ...Option) *SortedVector {
+	option := Options{
+		keyCmp: defaultKeyComparator,
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &SortedVector{
+		data:         make([]interface{}, 0, option.capacity),
+		keyCmp:       option.keyCmp,
+		growthFactor: option.growthFactor,
+		locker:       option.locker,
+	}
+}
+
+// Insert inserts value after the elements equal to it, and returns the position of value
+func (sv *SortedVector) Insert(value interface{}) int {
+	sv.locker.Lock()
+	defer sv.locker.Unlock()
+
+	pos := sv.upperBound(value)
+	if len(sv.data) == cap(sv.data) && sv.growthFactor > 1 {
+		newCapacity := int(float64(cap(sv.data)) * sv.growthFactor)
+		if newCapacity <= cap(sv.data) {
+			newCapacity = cap(sv.data) + 1
+		}
+		data := make([]interface{}, len(sv.data), newCapacity)
+		copy(data, sv.data)
+		sv.data = data
+	}
+	sv.data = append(sv.data, nil)
+	copy(sv.data[pos+1:], sv.data[pos:])
+	sv.data[pos] = value
+	return pos
+}
+
+// Erase erases the first element equal to value, and returns false if value not exist
+func (sv *SortedVector) Erase(value interface{}) bool {
+	sv.locker.Lock()
+	defer sv.locker.Unlock()
+
+	pos := sv.find(value)
+	if pos < 0 {
+		return false
+	}
+	sv.eraseAt(pos)
+	return true
+}
+
+// EraseAt erases the element at position, it does nothing if position is out of range
+func (sv *SortedVector) EraseAt(position int) {
+	sv.locker.Lock()
+	defer sv.locker.Unlock()
+
+	if position < 0 || position >= len(sv.data) {
+		return
+	}
+	sv.eraseAt(position)
+}
+
+func (sv *SortedVector) eraseAt(position int) {
+	copy(sv.data[position:], sv.data[position+1:])
+	sv.data[len(sv.data)-1] = nil // avoid memory leaks
+	sv.data = sv.data[:len(sv.data)-1]
+}
+
+// Contains returns true if value in the SortedVector, otherwise returns false
+func (sv *SortedVector) Contains(value interface{}) bool {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return sv.find(value) >= 0
+}
+
+// Find returns the position of the first element equal to value, or -1 if not exist
+func (sv *SortedVector) Find(value interface{}) int {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return sv.find(value)
+}
+
+func (sv *SortedVector) find(value interface{}) int {
+	pos := sv.lowerBound(value)
+	if pos < len(sv.data) && sv.keyCmp(sv.data[pos], value) == 0 {
+		return pos
+	}
+	return -1
+}
+
+// LowerBound returns the position of the first element that equal or greater than value, or Size() if not exist
+func (sv *SortedVector) LowerBound(value interface{}) int {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return sv.lowerBound(value)
+}
+
+func (sv *SortedVector) lowerBound(value interface{}) int {
+	return sort.Search(len(sv.data), func(i int) bool {
+		return sv.keyCmp(sv.data[i], value) >= 0
+	})
+}
+
+// UpperBound returns the position of the first element that greater than value, or Size() if not exist
+func (sv *SortedVector) UpperBound(value interface{}) int {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return sv.upperBound(value)
+}
+
+func (sv *SortedVector) upperBound(value interface{}) int {
+	return sort.Search(len(sv.data), func(i int) bool {
+		return sv.keyCmp(sv.data[i], value) > 0
+	})
+}
+
+// At returns the element at position, or nil if position is out of range
+func (sv *SortedVector) At(position int) interface{} {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	if position < 0 || position >= len(sv.data) {
+		return nil
+	}
+	return sv.data[position]
+}
+
+// Capacity returns the capacity of the SortedVector
+func (sv *SortedVector) Capacity() int {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return cap(sv.data)
+}
+
+// Size returns the number of elements in the SortedVector
+func (sv *SortedVector) Size() int {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return len(sv.data)
+}
+
+// Empty returns true if the SortedVector contains no elements
+func (sv *SortedVector) Empty() bool {
+	return sv.Size() == 0
+}
+
+// Clear clears the SortedVector, it keeps the capacity for the following inserts
+func (sv *SortedVector) Clear() {
+	sv.locker.Lock()
+	defer sv.locker.Unlock()
+
+	for i := range sv.data {
+		sv.data[i] = nil // avoid memory leaks
+	}
+	sv.data = sv.data[:0]
+}
+
+// Values returns a copy of all elements in ascending order
+func (sv *SortedVector) Values() []interface{} {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	values := make([]interface{}, len(sv.data))
+	copy(values, sv.data)
+	return values
+}
+
+// Traversal traversals elements in ascending order, it will not stop until to the end or visitor returns false
+func (sv *SortedVector) Traversal(visitor visitor.Visitor) {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	for _, value := range sv.data {
+		if !visitor(value) {
+			break
+		}
+	}
+}
+
+// String returns the SortedVector's elements in string format
+func (sv *SortedVector) String() string {
+	sv.locker.RLock()
+	defer sv.locker.RUnlock()
+
+	return fmt.Sprintf("%v", sv.data)
+}
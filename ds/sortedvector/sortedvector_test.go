@@ -0,0 +1,109 @@
+package sortedvector
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSortedVector(t *testing.T) {
+	sv := New()
+	assert.True(t, sv.Empty())
+	assert.Equal(t, -1, sv.Find(1))
+	assert.Nil(t, sv.At(0))
+
+	for _, v := range []int{5, 1, 3, 3, 9} {
+		sv.Insert(v)
+	}
+	assert.Equal(t, "[1 3 3 5 9]", sv.String())
+	assert.Equal(t, 1, sv.Find(3))
+	assert.Equal(t, 1, sv.LowerBound(3))
+	assert.Equal(t, 3, sv.UpperBound(3))
+	assert.Equal(t, 5, sv.LowerBound(10))
+	assert.Equal(t, 9, sv.At(4))
+	assert.Nil(t, sv.At(5))
+
+	assert.True(t, sv.Erase(3))
+	assert.True(t, sv.Contains(3))
+	assert.True(t, sv.Erase(3))
+	assert.False(t, sv.Erase(3))
+	sv.EraseAt(0)
+	sv.EraseAt(10)
+	assert.Equal(t, []interface{}{5, 9}, sv.Values())
+
+	n := 0
+	sv.Traversal(func(value interface{}) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n)
+
+	capacity := sv.Capacity()
+	sv.Clear()
+	assert.Equal(t, 0, sv.Size())
+	assert.Equal(t, capacity, sv.Capacity())
+	// the backing array doesn't keep the cleared elements alive
+	for _, v := range sv.data[:cap(sv.data)] {
+		assert.Nil(t, v)
+	}
+}
+
+func TestSortedVectorAgainstTreeMap(t *testing.T) {
+	sv := New(WithKeyComparator(comparator.Reverse(comparator.IntComparator)), WithGoroutineSafe())
+	m := treemap.New(treemap.WithKeyComparator(comparator.Reverse(comparator.IntComparator)))
+	for i := 0; i < 2000; i++ {
+		v := rand.Intn(1000)
+		if !sv.Contains(v) {
+			sv.Insert(v)
+		}
+		m.Insert(v, v)
+	}
+	assert.Equal(t, m.Keys(), sv.Values())
+	for v := -1; v <= 1000; v++ {
+		assert.Equal(t, m.Contains(v), sv.Contains(v))
+	}
+	for i := 0; i < sv.Size(); i++ {
+		assert.Equal(t, m.Keys()[i], sv.At(i))
+	}
+}
+
+func TestSortedVectorCapacity(t *testing.T) {
+	sv := New(WithCapacity(10))
+	assert.Equal(t, 10, sv.Capacity())
+	for i := 0; i < 10; i++ {
+		sv.Insert(i)
+	}
+	assert.Equal(t, 10, sv.Capacity())
+
+	sv = New(WithCapacity(4), WithGrowthFactor(1.5))
+	for i := 0; i < 5; i++ {
+		sv.Insert(i)
+	}
+	assert.Equal(t, 6, sv.Capacity())
+	sv = New(WithGrowthFactor(1.01))
+	for i := 0; i < 3; i++ {
+		sv.Insert(3 - i)
+	}
+	assert.Equal(t, 3, sv.Capacity())
+	assert.Equal(t, []interface{}{1, 2, 3}, sv.Values())
+}
+
+func benchmarkSortedVectorInsert(b *testing.B, opts ...Option) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sv := New(opts...)
+		for j := 0; j < 1000; j++ {
+			sv.Insert(j % 256) // small integers don't allocate when converted to interface{}
+		}
+	}
+}
+
+func BenchmarkSortedVectorInsert(b *testing.B) {
+	benchmarkSortedVectorInsert(b)
+}
+
+func BenchmarkSortedVectorInsertWithCapacity(b *testing.B) {
+	benchmarkSortedVectorInsert(b, WithCapacity(1000))
+}
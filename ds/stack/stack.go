@@ -9,8 +9,7 @@ import (
 )
 
 var (
-	defaultLocker    sync.FakeLocker
-	defaultContainer = deque.New()
+	defaultLocker sync.FakeLocker
 )
 
 // Options holds Stack's options
@@ -52,12 +51,14 @@ type Stack struct {
 // New news Stack
 func New(opts ...Option) *Stack {
 	option := Options{
-		locker:    defaultLocker,
-		container: defaultContainer,
+		locker: defaultLocker,
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
+	if option.container == nil {
+		option.container = deque.New()
+	}
 
 	return &Stack{
 		container: option.container,
@@ -89,20 +90,32 @@ func (s *Stack) Push(value interface{}) {
 	s.container.PushBack(value)
 }
 
-// Top returns the top value in s
+// Top returns the top value in s, or nil if s is empty
 func (s *Stack) Top() interface{} {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
+	if s.container.Empty() {
+		return nil
+	}
 	return s.container.Back()
 }
 
-// Pop removes the the top item in s, and returns it's value
+// Pop removes the the top item in s, and returns it's value, or nil if s is empty
 func (s *Stack) Pop() interface{} {
+	value, _ := s.TryPop()
+	return value
+}
+
+// TryPop removes the the top item in s, and returns it's value and true, or nil and false if s is empty
+func (s *Stack) TryPop() (interface{}, bool) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	return s.container.PopBack()
+	if s.container.Empty() {
+		return nil, false
+	}
+	return s.container.PopBack(), true
 }
 
 // Clear clears all items in s
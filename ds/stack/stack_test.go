@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -51,3 +53,50 @@ func TestStackWithListContainer(t *testing.T) {
 		t.Fatalf("expect true, but get false")
 	}
 }
+
+func TestStackTryPop(t *testing.T) {
+	s := New()
+	if v, ok := s.TryPop(); ok || v != nil {
+		t.Fatalf("expect nil and false, but get %v and %v", v, ok)
+	}
+	if s.Pop() != nil || s.Top() != nil {
+		t.Fatalf("expect nil")
+	}
+	// interleaved push and pop
+	for i := 0; i < 100; i++ {
+		s.Push(i)
+		s.Push(i)
+		if v, ok := s.TryPop(); !ok || v != i {
+			t.Fatalf("expect %v and true, but get %v and %v", i, v, ok)
+		}
+	}
+	if s.Size() != 100 {
+		t.Fatalf("expect %v, but get %v", 100, s.Size())
+	}
+	// every Stack has its own container
+	if New().Size() != 0 {
+		t.Fatalf("expect a new stack to be empty")
+	}
+}
+
+func TestStackGoroutineSafe(t *testing.T) {
+	s := New(WithGoroutineSafe())
+	wg := sync.WaitGroup{}
+	var popped int64
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				s.Push(i)
+				if _, ok := s.TryPop(); ok {
+					atomic.AddInt64(&popped, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if int(popped)+s.Size() != 4000 {
+		t.Fatalf("expect %v, but get %v", 4000, int(popped)+s.Size())
+	}
+}
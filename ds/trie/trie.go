@@ -0,0 +1,200 @@
+package trie
+
+import (
+	"github.com/liyue201/gostl/utils/sync"
+	"sort"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds Trie's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets Trie goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+type node struct {
+	children map[byte]*node
+	value    interface{}
+	end      bool
+}
+
+func newNode() *node {
+	return &node{children: make(map[byte]*node)}
+}
+
+// Trie is a prefix tree with string keys, it supports finding all the words with a given prefix
+type Trie struct {
+	root   *node
+	size   int
+	locker sync.Locker
+}
+
+// New creates a new Trie
+func New(opts ...Option) *Trie {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &Trie{
+		root:   newNode(),
+		locker: option.locker,
+	}
+}
+
+// Insert inserts a word with value to the Trie, the value will be replaced if word exists
+func (t *Trie) Insert(word string, value interface{}) {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	n := t.root
+	for i := 0; i < len(word); i++ {
+		child, ok := n.children[word[i]]
+		if !ok {
+			child = newNode()
+			n.children[word[i]] = child
+		}
+		n = child
+	}
+	if !n.end {
+		n.end = true
+		t.size++
+	}
+	n.value = value
+}
+
+// Get returns the value of word and true if found, or nil and false if not found
+func (t *Trie) Get(word string) (interface{}, bool) {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	n := t.find(word)
+	if n == nil || !n.end {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Contains returns true if word is in the Trie, otherwise returns false
+func (t *Trie) Contains(word string) bool {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	n := t.find(word)
+	return n != nil && n.end
+}
+
+// Erase erases word from the Trie and returns true if it was in the Trie, the nodes no longer used are removed
+func (t *Trie) Erase(word string) bool {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	path := make([]*node, 0, len(word)+1)
+	n := t.root
+	path = append(path, n)
+	for i := 0; i < len(word); i++ {
+		n = n.children[word[i]]
+		if n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.end {
+		return false
+	}
+	n.end = false
+	n.value = nil
+	t.size--
+
+	for i := len(word); i > 0; i-- {
+		if path[i].end || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, word[i-1])
+	}
+	return true
+}
+
+// Size returns the number of words in the Trie
+func (t *Trie) Size() int {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	return t.size
+}
+
+// IsEmpty returns true if the Trie is empty, otherwise returns false
+func (t *Trie) IsEmpty() bool {
+	return t.Size() == 0
+}
+
+// Clear clears the Trie
+func (t *Trie) Clear() {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.root = newNode()
+	t.size = 0
+}
+
+// WithPrefix returns all the words with prefix in ascending order, all the words are returned if prefix is empty
+func (t *Trie) WithPrefix(prefix string) []string {
+	words := make([]string, 0)
+	t.Walk(prefix, func(word string, value interface{}) {
+		words = append(words, word)
+	})
+	return words
+}
+
+// Walk calls fn for every word with prefix and its value in ascending order of words.
+// Note that the Trie is read-locked during the walking, so fn must not modify the Trie.
+func (t *Trie) Walk(prefix string, fn func(word string, value interface{})) {
+	t.locker.RLock()
+	defer t.locker.RUnlock()
+
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+	buf := []byte(prefix)
+	walk(n, buf, fn)
+}
+
+func (t *Trie) find(word string) *node {
+	n := t.root
+	for i := 0; i < len(word); i++ {
+		n = n.children[word[i]]
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func walk(n *node, buf []byte, fn func(word string, value interface{})) {
+	if n.end {
+		fn(string(buf), n.value)
+	}
+	keys := make([]int, 0, len(n.children))
+	for c := range n.children {
+		keys = append(keys, int(c))
+	}
+	sort.Ints(keys)
+	for _, c := range keys {
+		walk(n.children[byte(c)], append(buf, byte(c)), fn)
+	}
+}
@@ -0,0 +1,67 @@
+package trie
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	tr := New()
+	assert.True(t, tr.IsEmpty())
+
+	words := []string{"tea", "ten", "to", "inn", "in", "i", "team", "tea"}
+	for i, w := range words {
+		tr.Insert(w, i)
+	}
+	assert.Equal(t, 7, tr.Size())
+
+	v, ok := tr.Get("tea")
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+	_, ok = tr.Get("te")
+	assert.False(t, ok)
+	assert.True(t, tr.Contains("i"))
+	assert.False(t, tr.Contains("t"))
+	assert.False(t, tr.Contains("teams"))
+
+	assert.Equal(t, []string{"tea", "team", "ten"}, tr.WithPrefix("te"))
+	assert.Equal(t, []string{"tea", "team"}, tr.WithPrefix("tea"))
+	assert.Equal(t, []string{"i", "in", "inn"}, tr.WithPrefix("i"))
+	assert.Equal(t, []string{}, tr.WithPrefix("x"))
+	assert.Equal(t, []string{"i", "in", "inn", "tea", "team", "ten", "to"}, tr.WithPrefix(""))
+
+	values := make(map[string]interface{})
+	tr.Walk("t", func(word string, value interface{}) {
+		values[word] = value
+	})
+	assert.Equal(t, map[string]interface{}{"tea": 7, "team": 6, "ten": 1, "to": 2}, values)
+}
+
+func TestTrieErase(t *testing.T) {
+	tr := New(WithGoroutineSafe())
+	for _, w := range []string{"a", "ab", "abc", "abd", "b"} {
+		tr.Insert(w, nil)
+	}
+	assert.False(t, tr.Erase("abx"))
+	assert.False(t, tr.Erase("x"))
+
+	assert.True(t, tr.Erase("ab"))
+	assert.False(t, tr.Contains("ab"))
+	assert.Equal(t, []string{"a", "abc", "abd"}, tr.WithPrefix("a"))
+	assert.False(t, tr.Erase("ab"))
+
+	assert.True(t, tr.Erase("abc"))
+	assert.True(t, tr.Erase("abd"))
+	// the unused nodes are removed
+	assert.Equal(t, 0, len(tr.root.children['a'].children))
+	assert.Equal(t, []string{"a", "b"}, tr.WithPrefix(""))
+	assert.Equal(t, 2, tr.Size())
+
+	// nil values are stored too
+	_, ok := tr.Get("a")
+	assert.True(t, ok)
+
+	tr.Clear()
+	assert.True(t, tr.IsEmpty())
+	assert.Equal(t, []string{}, tr.WithPrefix(""))
+}
@@ -0,0 +1,149 @@
+package unionfind
+
+import (
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds UnionFind's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe sets UnionFind goroutine-safety
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+type node struct {
+	element interface{}
+	parent  *node
+	rank    int
+}
+
+// UnionFind is a disjoint-set data structure, it keeps track of elements partitioned into disjoint sets.
+// It uses path compression and union by rank, so its operations take nearly constant amortized time.
+// Elements must be comparable, the elements not added by MakeSet are added as singleton sets the first time they are used.
+type UnionFind struct {
+	nodes  map[interface{}]*node
+	count  int
+	locker sync.Locker
+}
+
+// New creates a new UnionFind
+func New(opts ...Option) *UnionFind {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &UnionFind{
+		nodes:  make(map[interface{}]*node),
+		locker: option.locker,
+	}
+}
+
+// MakeSet adds x as a singleton set if x is not in the UnionFind
+func (uf *UnionFind) MakeSet(x interface{}) {
+	uf.locker.Lock()
+	defer uf.locker.Unlock()
+
+	uf.nodeOf(x)
+}
+
+// Find returns the representative element of the set containing x
+func (uf *UnionFind) Find(x interface{}) interface{} {
+	uf.locker.Lock()
+	defer uf.locker.Unlock()
+
+	return uf.find(uf.nodeOf(x)).element
+}
+
+// Union merges the sets containing x and y, it returns false if they are already in the same set
+func (uf *UnionFind) Union(x, y interface{}) bool {
+	uf.locker.Lock()
+	defer uf.locker.Unlock()
+
+	rx := uf.find(uf.nodeOf(x))
+	ry := uf.find(uf.nodeOf(y))
+	if rx == ry {
+		return false
+	}
+	if rx.rank < ry.rank {
+		rx, ry = ry, rx
+	}
+	ry.parent = rx
+	if rx.rank == ry.rank {
+		rx.rank++
+	}
+	uf.count--
+	return true
+}
+
+// Connected returns true if x and y are in the same set, otherwise returns false
+func (uf *UnionFind) Connected(x, y interface{}) bool {
+	uf.locker.Lock()
+	defer uf.locker.Unlock()
+
+	return uf.find(uf.nodeOf(x)) == uf.find(uf.nodeOf(y))
+}
+
+// Count returns the number of disjoint sets
+func (uf *UnionFind) Count() int {
+	uf.locker.RLock()
+	defer uf.locker.RUnlock()
+
+	return uf.count
+}
+
+// Size returns the number of elements
+func (uf *UnionFind) Size() int {
+	uf.locker.RLock()
+	defer uf.locker.RUnlock()
+
+	return len(uf.nodes)
+}
+
+// Contains returns true if x has been added to the UnionFind, otherwise returns false
+func (uf *UnionFind) Contains(x interface{}) bool {
+	uf.locker.RLock()
+	defer uf.locker.RUnlock()
+
+	_, ok := uf.nodes[x]
+	return ok
+}
+
+func (uf *UnionFind) nodeOf(x interface{}) *node {
+	n, ok := uf.nodes[x]
+	if !ok {
+		n = &node{element: x}
+		n.parent = n
+		uf.nodes[x] = n
+		uf.count++
+	}
+	return n
+}
+
+// find returns the root of n and makes all the nodes on the path point to the root directly
+func (uf *UnionFind) find(n *node) *node {
+	root := n
+	for root.parent != root {
+		root = root.parent
+	}
+	for n != root {
+		next := n.parent
+		n.parent = root
+		n = next
+	}
+	return root
+}
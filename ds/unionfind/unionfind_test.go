@@ -0,0 +1,67 @@
+package unionfind
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := New()
+	for i := 0; i < 10; i++ {
+		uf.MakeSet(i)
+	}
+	assert.Equal(t, 10, uf.Count())
+	assert.Equal(t, 10, uf.Size())
+	assert.False(t, uf.Connected(1, 2))
+
+	unions := [][2]int{{0, 1}, {2, 3}, {1, 3}, {4, 5}, {6, 7}, {5, 7}}
+	for i, u := range unions {
+		assert.True(t, uf.Union(u[0], u[1]))
+		assert.Equal(t, 10-i-1, uf.Count())
+	}
+	// 0-1-2-3, 4-5-6-7, 8, 9
+	assert.False(t, uf.Union(0, 3))
+	assert.Equal(t, 4, uf.Count())
+
+	assert.True(t, uf.Connected(0, 2))
+	assert.True(t, uf.Connected(4, 7))
+	assert.False(t, uf.Connected(3, 4))
+	assert.False(t, uf.Connected(8, 9))
+	assert.Equal(t, uf.Find(0), uf.Find(3))
+	assert.NotEqual(t, uf.Find(0), uf.Find(4))
+	assert.Equal(t, 8, uf.Find(8))
+
+	assert.True(t, uf.Union(3, 4))
+	assert.True(t, uf.Connected(0, 7))
+	assert.Equal(t, 3, uf.Count())
+}
+
+func TestUnionFindImplicitElements(t *testing.T) {
+	uf := New(WithGoroutineSafe())
+	assert.False(t, uf.Contains("a"))
+	assert.Equal(t, "a", uf.Find("a"))
+	assert.True(t, uf.Contains("a"))
+	assert.Equal(t, 1, uf.Count())
+
+	uf.Union("b", "c")
+	assert.Equal(t, 2, uf.Count())
+	assert.Equal(t, 3, uf.Size())
+
+	uf.MakeSet("b")
+	assert.True(t, uf.Connected("b", "c"))
+}
+
+func TestUnionFindPathCompression(t *testing.T) {
+	uf := New()
+	n := 1000
+	for i := 1; i < n; i++ {
+		uf.Union(i-1, i)
+	}
+	assert.Equal(t, 1, uf.Count())
+	root := uf.Find(n - 1)
+	for i := 0; i < n; i++ {
+		assert.Equal(t, root, uf.Find(i))
+		// after Find, every node points to the root directly
+		assert.Equal(t, root, uf.nodes[i].parent.element)
+	}
+}
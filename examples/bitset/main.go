@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/bitset"
+)
+
+func main() {
+	a := bitset.New(64)
+	a.Set(1)
+	a.Set(100) // grows automatically
+
+	b := bitset.New(64)
+	b.Set(1)
+	b.Set(2)
+
+	fmt.Printf("%v %v %v\n", a.Test(1), a.Test(2), a.Test(100))
+	fmt.Printf("%v\n", a.And(b).Count())
+	fmt.Printf("%v\n", a.Or(b).Count())
+	fmt.Printf("%v\n", a.Xor(b).Count())
+}
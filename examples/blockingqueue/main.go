@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/blockingqueue"
+	"time"
+)
+
+func main() {
+	bq := blockingqueue.New(2)
+	go func() {
+		for i := 0; i < 5; i++ {
+			bq.Put(i)
+		}
+		bq.Close()
+	}()
+
+	for {
+		v, ok := bq.Take()
+		if !ok {
+			break
+		}
+		fmt.Printf("%v ", v)
+	}
+	fmt.Println()
+
+	_, ok := blockingqueue.New(1).Poll(10 * time.Millisecond)
+	fmt.Printf("%v\n", ok)
+}
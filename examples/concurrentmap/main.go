@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/concurrentmap"
+	"sync"
+)
+
+func main() {
+	m := concurrentmap.New(concurrentmap.WithShardCount(16))
+	wg := sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Insert(g*100+i, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	value, ok := m.Get(150)
+	fmt.Printf("%v %v %v\n", m.Size(), value, ok)
+}
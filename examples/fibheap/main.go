@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/fibheap"
+)
+
+func main() {
+	h := fibheap.New()
+	h.Insert(5, "a")
+	b := h.Insert(8, "b")
+	h.Insert(3, "c")
+
+	h.DecreaseKey(b, 1)
+
+	for !h.Empty() {
+		n := h.ExtractMin()
+		fmt.Printf("%v:%v ", n.Key(), n.Value())
+	}
+	fmt.Println()
+}
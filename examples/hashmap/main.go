@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/hashmap"
+)
+
+func main() {
+	m := hashmap.New(hashmap.WithGoroutineSafe())
+	m.Insert("a", "aaa")
+	m.Insert("b", "bbb")
+
+	value, ok := m.Get("a")
+	fmt.Printf("%v %v\n", value, ok)
+	_, ok = m.Get("c")
+	fmt.Printf("%v\n", ok)
+
+	m.Erase("b")
+	fmt.Printf("%v\n", m.Size())
+}
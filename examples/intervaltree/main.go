@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/intervaltree"
+)
+
+func main() {
+	t := intervaltree.New()
+	t.Insert(1, 5, "a")
+	t.Insert(3, 8, "b")
+	t.Insert(10, 12, "c")
+
+	for _, interval := range t.QueryOverlap(5, 10) {
+		fmt.Printf("[%v, %v] %v\n", interval.Low, interval.High, interval.Value)
+	}
+
+	t.Remove(3, 8)
+	fmt.Printf("%v\n", t.Size())
+}
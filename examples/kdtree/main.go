@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/kdtree"
+)
+
+func main() {
+	tree := kdtree.New(2)
+	tree.Insert([]float64{2, 3}, "a")
+	tree.Insert([]float64{5, 4}, "b")
+	tree.Insert([]float64{9, 6}, "c")
+	tree.Insert([]float64{8, 1}, "d")
+
+	value, dist := tree.Nearest([]float64{9, 2})
+	fmt.Printf("%v %.3f\n", value, dist)
+
+	fmt.Printf("%v\n", tree.RangeSearch([]float64{4, 0}, []float64{9, 5}))
+}
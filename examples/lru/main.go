@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/lru"
+)
+
+func main() {
+	cache := lru.New(2, lru.WithOnEvict(func(key, value interface{}) {
+		fmt.Printf("evict %v\n", key)
+	}))
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a")    // "a" becomes the most recently used
+	cache.Put("c", 3) // evicts "b"
+
+	fmt.Printf("%v\n", cache.Keys())
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/radixmap"
+)
+
+func main() {
+	m := radixmap.New()
+	m.Insert("/api/users", 1)
+	m.Insert("/api/users/settings", 2)
+	m.Insert("/api/orders", 3)
+
+	value, ok := m.Get("/api/users")
+	fmt.Printf("%v %v\n", value, ok)
+
+	fmt.Printf("%v\n", m.WithPrefix("/api/u"))
+
+	m.Erase("/api/users")
+	fmt.Printf("%v\n", m.Keys())
+}
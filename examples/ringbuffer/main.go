@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/ringbuffer"
+)
+
+func main() {
+	rb := ringbuffer.New(3)
+	for i := 1; i <= 5; i++ {
+		rb.Push(i)
+	}
+	fmt.Printf("%v\n", rb.Values())
+
+	oldest, _ := rb.Oldest()
+	newest, _ := rb.Newest()
+	fmt.Printf("%v %v\n", oldest, newest)
+
+	fmt.Printf("%v\n", rb.PushNoOverwrite(6))
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/segtree"
+)
+
+func main() {
+	st := segtree.New([]int64{5, 3, 8, 1, 9}, func(a, b int64) int64 {
+		return a + b
+	}, 0)
+	fmt.Printf("%v\n", st.Query(1, 4))
+
+	st.Update(2, 10)
+	fmt.Printf("%v\n", st.Query(0, st.Len()))
+}
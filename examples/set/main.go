@@ -19,6 +19,10 @@ func main() {
 		fmt.Printf("%v\n", iter.Value())
 	}
 
+	s.ForEach(func(value interface{}) {
+		fmt.Printf("%v\n", value)
+	})
+
 	fmt.Printf("%v\n", s.Contains(3))
 	fmt.Printf("%v\n", s.Contains(10))
 }
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/sortedlog"
+)
+
+func main() {
+	l := sortedlog.New()
+	l.Append(100, "start")
+	l.Append(105, "tick")
+	l.Append(110, "tick")
+	fmt.Printf("%v\n", l.Append(103, "late"))
+
+	v, _ := l.Find(105)
+	fmt.Printf("%v %v\n", v, l.LowerBound(106))
+
+	l.Range(101, 111, func(key, value interface{}) bool {
+		fmt.Printf("%v:%v\n", key, value)
+		return true
+	})
+}
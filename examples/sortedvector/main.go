@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/sortedvector"
+	"github.com/liyue201/gostl/utils/comparator"
+)
+
+func main() {
+	sv := sortedvector.New(sortedvector.WithKeyComparator(comparator.IntComparator))
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		sv.Insert(v)
+	}
+	fmt.Printf("%v\n", sv)
+	fmt.Printf("%v %v\n", sv.Contains(3), sv.Find(4))
+	fmt.Printf("%v\n", sv.At(0))
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/trie"
+)
+
+func main() {
+	t := trie.New()
+	t.Insert("tea", 1)
+	t.Insert("ten", 2)
+	t.Insert("to", 3)
+
+	fmt.Printf("%v\n", t.WithPrefix("te"))
+
+	t.Walk("t", func(word string, value interface{}) {
+		fmt.Printf("%v:%v ", word, value)
+	})
+	fmt.Println()
+
+	t.Erase("tea")
+	fmt.Printf("%v\n", t.Contains("tea"))
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"github.com/liyue201/gostl/ds/unionfind"
+)
+
+func main() {
+	uf := unionfind.New()
+	uf.Union("a", "b")
+	uf.Union("c", "d")
+	uf.MakeSet("e")
+
+	fmt.Printf("%v\n", uf.Count())
+	fmt.Printf("%v\n", uf.Connected("a", "b"))
+	fmt.Printf("%v\n", uf.Connected("a", "c"))
+
+	uf.Union("b", "c")
+	fmt.Printf("%v %v\n", uf.Connected("a", "d"), uf.Count())
+}
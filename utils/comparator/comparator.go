@@ -1,5 +1,7 @@
 package comparator
 
+import "reflect"
+
 // Comparator Should return a number:
 //    -1 , if a < b
 //    0  , if a == b
@@ -126,6 +128,56 @@ func Reverse(cmp Comparator) Comparator {
 	}
 }
 
+//Chain returns a comparator which applies cmps in turn and returns the first non-zero result,
+//the remaining comparators are not called once a non-zero result is found. It returns 0 if all of cmps return 0.
+func Chain(cmps ...Comparator) Comparator {
+	return func(a, b interface{}) int {
+		for _, cmp := range cmps {
+			if r := cmp(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+//NullsFirst returns a comparator which orders nils before all non-nil values and delegates to cmp if both a and b are not nil.
+//Both the nil interface and the typed nil pointers, maps, slices, channels, functions and interfaces are treated as nil.
+func NullsFirst(cmp Comparator) Comparator {
+	return nullable(cmp, -1)
+}
+
+//NullsLast returns a comparator which orders nils after all non-nil values and delegates to cmp if both a and b are not nil.
+func NullsLast(cmp Comparator) Comparator {
+	return nullable(cmp, 1)
+}
+
+func nullable(cmp Comparator, nilOrder int) Comparator {
+	return func(a, b interface{}) int {
+		aNil, bNil := isNil(a), isNil(b)
+		switch {
+		case aNil && bNil:
+			return 0
+		case aNil:
+			return nilOrder
+		case bNil:
+			return -nilOrder
+		}
+		return cmp(a, b)
+	}
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
 // IntComparator compare a with b
 //    -1 , if a < b
 //    0  , if a == b
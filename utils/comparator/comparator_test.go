@@ -0,0 +1,130 @@
+package comparator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	cmp := Reverse(BuiltinTypeComparator)
+	assert.Equal(t, 1, cmp(1, 2))
+	assert.Equal(t, -1, cmp(2, 1))
+	assert.Equal(t, 0, cmp(2, 2))
+	assert.Equal(t, 0, cmp("a", "a"))
+
+	cmp = Reverse(Reverse(IntComparator))
+	assert.Equal(t, -1, cmp(1, 2))
+	assert.Equal(t, 0, cmp(1, 1))
+}
+
+func TestTypedComparator(t *testing.T) {
+	assert.Equal(t, -1, IntComparator(1, 2))
+	assert.Equal(t, 0, IntComparator(2, 2))
+	assert.Equal(t, 1, IntComparator(3, 2))
+	assert.Equal(t, -1, StringComparator("a", "b"))
+	assert.Equal(t, 0, StringComparator("b", "b"))
+	assert.Equal(t, 1, StringComparator("c", "b"))
+	assert.Equal(t, -1, Float64Comparator(1.5, 2.5))
+	assert.Equal(t, 0, Float64Comparator(2.5, 2.5))
+	assert.Equal(t, 1, Float64Comparator(3.5, 2.5))
+}
+
+type person struct {
+	lastName  string
+	firstName string
+}
+
+func TestChain(t *testing.T) {
+	byLastName := func(a, b interface{}) int {
+		return StringComparator(a.(person).lastName, b.(person).lastName)
+	}
+	byFirstName := func(a, b interface{}) int {
+		return StringComparator(a.(person).firstName, b.(person).firstName)
+	}
+	cmp := Chain(byLastName, byFirstName)
+
+	people := []person{{"Smith", "John"}, {"Doe", "Jane"}, {"Smith", "Adam"}, {"Doe", "Alice"}}
+	sort.Slice(people, func(i, j int) bool {
+		return cmp(people[i], people[j]) < 0
+	})
+	assert.Equal(t, []person{{"Doe", "Alice"}, {"Doe", "Jane"}, {"Smith", "Adam"}, {"Smith", "John"}}, people)
+	assert.Equal(t, 0, cmp(person{"Doe", "Jane"}, person{"Doe", "Jane"}))
+
+	called := false
+	cmp = Chain(byLastName, func(a, b interface{}) int {
+		called = true
+		return 0
+	})
+	assert.Equal(t, -1, cmp(person{"Doe", "Jane"}, person{"Smith", "Jane"}))
+	assert.False(t, called)
+
+	assert.Equal(t, 0, Chain()(1, 2))
+}
+
+func TestNullsFirstLast(t *testing.T) {
+	one, two := 1, 2
+	var nilPtr *int
+	byPointee := func(a, b interface{}) int {
+		return IntComparator(*a.(*int), *b.(*int))
+	}
+	keys := []interface{}{&two, nil, &one, nilPtr}
+
+	cmp := NullsFirst(byPointee)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return cmp(keys[i], keys[j]) < 0
+	})
+	assert.True(t, isNil(keys[0]) && isNil(keys[1]))
+	assert.Equal(t, []interface{}{&one, &two}, keys[2:])
+	assert.Equal(t, 0, cmp(nil, nilPtr))
+
+	cmp = NullsLast(byPointee)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return cmp(keys[i], keys[j]) < 0
+	})
+	assert.Equal(t, []interface{}{&one, &two}, keys[:2])
+	assert.True(t, isNil(keys[2]) && isNil(keys[3]))
+	assert.Equal(t, 1, cmp(nil, &one))
+	assert.Equal(t, -1, cmp(&one, nil))
+
+	strCmp := NullsFirst(StringComparator)
+	assert.Equal(t, -1, strCmp(nil, ""))
+	assert.Equal(t, 1, strCmp("a", nil))
+	assert.Equal(t, -1, strCmp("a", "b"))
+}
+
+// the bounds of int and uint on the current platform, math.MaxInt and math.MaxUint need go1.17
+const (
+	maxUint = ^uint(0)
+	maxInt  = int(maxUint >> 1)
+	minInt  = -maxInt - 1
+)
+
+func TestBuiltinTypeComparatorExtremes(t *testing.T) {
+	// these pairs overflow if compared by subtraction
+	pairs := [][2]interface{}{
+		{minInt, maxInt},
+		{-1, maxInt},
+		{minInt, 1},
+		{int64(math.MinInt64), int64(math.MaxInt64)},
+		{int32(math.MinInt32), int32(math.MaxInt32)},
+		{int16(math.MinInt16), int16(math.MaxInt16)},
+		{int8(math.MinInt8), int8(math.MaxInt8)},
+		{uint64(0), uint64(math.MaxUint64)},
+		{uint32(1), uint32(math.MaxUint32)},
+		{uint(0), maxUint},
+		{-math.MaxFloat64, math.MaxFloat64},
+		{float32(-math.MaxFloat32), float32(math.MaxFloat32)},
+		{math.Inf(-1), math.Inf(1)},
+	}
+	for _, p := range pairs {
+		assert.Equal(t, -1, BuiltinTypeComparator(p[0], p[1]), "%v < %v", p[0], p[1])
+		assert.Equal(t, 1, BuiltinTypeComparator(p[1], p[0]), "%v > %v", p[1], p[0])
+		assert.Equal(t, 0, BuiltinTypeComparator(p[0], p[0]))
+	}
+	assert.Equal(t, -1, IntComparator(minInt, maxInt))
+	assert.Equal(t, 1, Int64Comparator(int64(math.MaxInt64), int64(math.MinInt64)))
+	assert.Equal(t, -1, Uint64Comparator(uint64(0), uint64(math.MaxUint64)))
+	assert.Equal(t, -1, Int8Comparator(int8(math.MinInt8), int8(math.MaxInt8)))
+}
@@ -0,0 +1,42 @@
+package comparator
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+)
+
+// TimeComparator compare a with b chronologically
+//    -1 , if a < b
+//    0  , if a == b
+//    1  , if a > b
+// make sure a and b are both time.Time
+func TimeComparator(a, b interface{}) int {
+	ta := a.(time.Time)
+	tb := b.(time.Time)
+	if ta.Equal(tb) {
+		return 0
+	}
+	if ta.Before(tb) {
+		return -1
+	}
+	return 1
+}
+
+// BigIntComparator compare a with b
+//    -1 , if a < b
+//    0  , if a == b
+//    1  , if a > b
+// make sure a and b are both *big.Int
+func BigIntComparator(a, b interface{}) int {
+	return a.(*big.Int).Cmp(b.(*big.Int))
+}
+
+// BytesComparator compare a with b lexicographically
+//    -1 , if a < b
+//    0  , if a == b
+//    1  , if a > b
+// make sure a and b are both []byte
+func BytesComparator(a, b interface{}) int {
+	return bytes.Compare(a.([]byte), b.([]byte))
+}
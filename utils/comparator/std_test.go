@@ -0,0 +1,72 @@
+package comparator_test
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTimeComparator(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := treemap.New(treemap.WithKeyComparator(comparator.TimeComparator))
+	for _, hours := range []int{5, -3, 0, 12, 1} {
+		m.Insert(base.Add(time.Duration(hours)*time.Hour), hours)
+	}
+	assert.Equal(t, []interface{}{-3, 0, 1, 5, 12}, m.Values())
+
+	// the same instant in another location is equal
+	assert.Equal(t, 0, comparator.TimeComparator(base, base.In(time.FixedZone("X", 3600))))
+	assert.Equal(t, 0, m.Get(base.In(time.FixedZone("X", 3600))))
+}
+
+func TestBigIntComparator(t *testing.T) {
+	huge, _ := new(big.Int).SetString("100000000000000000000000000000", 10)
+	assert.Equal(t, -1, comparator.BigIntComparator(big.NewInt(-1), huge))
+	assert.Equal(t, 1, comparator.BigIntComparator(huge, big.NewInt(1)))
+	assert.Equal(t, 0, comparator.BigIntComparator(big.NewInt(7), big.NewInt(7)))
+}
+
+func TestBytesComparator(t *testing.T) {
+	m := treemap.New(treemap.WithKeyComparator(comparator.BytesComparator))
+	for _, key := range []string{"abc", "ab", "abd", "a", "", "b"} {
+		m.Insert([]byte(key), key)
+	}
+	assert.Equal(t, []interface{}{"", "a", "ab", "abc", "abd", "b"}, m.Values())
+	assert.Equal(t, "ab", m.Get([]byte("ab")))
+	assert.Equal(t, 0, comparator.BytesComparator([]byte(nil), []byte{}))
+}
+
+func TestNullsLastMapKeys(t *testing.T) {
+	m := treemap.New(treemap.WithKeyComparator(comparator.NullsLast(comparator.StringComparator)))
+	m.Insert("b", 2)
+	m.Insert(nil, 0)
+	m.Insert("a", 1)
+
+	assert.Equal(t, []interface{}{"a", "b", nil}, m.Keys())
+	assert.Equal(t, 0, m.Get(nil))
+	assert.Nil(t, m.Verify())
+}
+
+func TestMapExtremeIntKeys(t *testing.T) {
+	// the bounds of int on the current platform, math.MaxInt needs go1.17
+	maxInt := int(^uint(0) >> 1)
+	minInt := -maxInt - 1
+	ints := []interface{}{minInt, minInt + 1, -1, 0, 1, maxInt - 1, maxInt}
+	int64s := []interface{}{int64(math.MinInt64), int64(math.MinInt64 + 1), int64(-1), int64(0), int64(1),
+		int64(math.MaxInt64 - 1), int64(math.MaxInt64)}
+	for _, keys := range [][]interface{}{ints, int64s} {
+		for i := 0; i < 10; i++ {
+			m := treemap.New()
+			for _, j := range rand.Perm(len(keys)) {
+				m.Insert(keys[j], nil)
+			}
+			assert.Equal(t, keys, m.Keys())
+			assert.Nil(t, m.Verify())
+		}
+	}
+}
@@ -9,6 +9,9 @@ type ConstIterator interface {
 	Equal(other ConstIterator) bool
 }
 
+// ForwardIterator is an iterator which moves forward by Next, every iterator is a ForwardIterator
+type ForwardIterator = ConstIterator
+
 // Iterator is an interface of mutable iterator
 type Iterator interface {
 	ConstIterator
@@ -0,0 +1,34 @@
+package iterator
+
+// Advance moves iter n steps forward in place, or -n steps backward if n is negative.
+// iter must be a ConstBidIterator when n is negative, otherwise Advance panics.
+func Advance(iter ForwardIterator, n int) {
+	if n < 0 {
+		bidIter, ok := iter.(ConstBidIterator)
+		if !ok {
+			panic("iterator: negative advance of a non-bidirectional iterator")
+		}
+		for ; n < 0; n++ {
+			bidIter.Prev()
+		}
+		return
+	}
+	for ; n > 0; n-- {
+		iter.Next()
+	}
+}
+
+// Distance returns the number of steps from first to last, last must be reachable from first.
+// It takes constant time for RandomAccessIterators, otherwise linear time.
+func Distance(first, last ConstIterator) int {
+	if firstIter, ok := first.(RandomAccessIterator); ok {
+		if lastIter, ok := last.(RandomAccessIterator); ok {
+			return lastIter.Position() - firstIter.Position()
+		}
+	}
+	n := 0
+	for iter := first.Clone(); !iter.Equal(last); iter.Next() {
+		n++
+	}
+	return n
+}
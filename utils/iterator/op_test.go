@@ -0,0 +1,42 @@
+package iterator_test
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/ds/skiplist"
+	"github.com/liyue201/gostl/ds/vector"
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdvanceDistance(t *testing.T) {
+	m := treemap.New()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	iter := m.Begin()
+	iterator.Advance(iter, 3)
+	assert.Equal(t, 3, iter.Key())
+	iterator.Advance(iter, -2)
+	assert.Equal(t, 1, iter.Key())
+	iterator.Advance(iter, 0)
+	assert.Equal(t, 1, iter.Key())
+
+	assert.Equal(t, 10, iterator.Distance(m.Begin(), m.UpperBound(9)))
+	assert.Equal(t, 4, iterator.Distance(m.Find(2), m.Find(6)))
+	assert.Equal(t, 0, iterator.Distance(m.Find(2), m.Find(2)))
+
+	v := vector.New()
+	for i := 0; i < 5; i++ {
+		v.PushBack(i)
+	}
+	assert.Equal(t, 5, iterator.Distance(v.Begin(), v.End()))
+	vIter := v.Begin()
+	iterator.Advance(vIter, 4)
+	assert.Equal(t, 4, vIter.Value())
+	assert.Equal(t, 1, iterator.Distance(vIter, v.End()))
+
+	l := skiplist.New()
+	l.Insert(1, 1)
+	assert.Panics(t, func() { iterator.Advance(l.Begin(), -1) })
+}
@@ -0,0 +1,125 @@
+package iterator
+
+import "math/rand"
+
+// StepIterator wraps an iterator and moves it k steps on each Next
+type StepIterator struct {
+	iter ConstIterator
+	k    int
+}
+
+// Step returns a StepIterator which visits every k-th element of iter starting from the current one,
+// iter is moved by the StepIterator. It panics if k is not positive.
+func Step(iter ConstIterator, k int) *StepIterator {
+	if k <= 0 {
+		panic("iterator: step must be positive")
+	}
+	return &StepIterator{iter: iter, k: k}
+}
+
+// IsValid returns whether iter is valid
+func (iter *StepIterator) IsValid() bool {
+	return iter.iter.IsValid()
+}
+
+// Next moves the wrapped iterator k steps forward, or until it becomes invalid, and returns iter
+func (iter *StepIterator) Next() ConstIterator {
+	for i := 0; i < iter.k && iter.iter.IsValid(); i++ {
+		iter.iter.Next()
+	}
+	return iter
+}
+
+// Value returns the value of the wrapped iterator
+func (iter *StepIterator) Value() interface{} {
+	return iter.iter.Value()
+}
+
+// Key returns the key of the wrapped iterator if it is a ConstKvIterator, otherwise returns nil
+func (iter *StepIterator) Key() interface{} {
+	return keyOf(iter.iter)
+}
+
+// Clone clones iter to a new StepIterator with a clone of the wrapped iterator
+func (iter *StepIterator) Clone() ConstIterator {
+	return &StepIterator{iter: iter.iter.Clone(), k: iter.k}
+}
+
+// Equal returns whether the wrapped iterators of iter and other are at the same position
+func (iter *StepIterator) Equal(other ConstIterator) bool {
+	return iter.iter.Equal(unwrap(other))
+}
+
+// SampleIterator wraps an iterator and skips each element with probability 1-rate
+type SampleIterator struct {
+	iter ConstIterator
+	rate float64
+	r    *rand.Rand
+}
+
+// Sample returns a SampleIterator which visits each element of iter with probability rate, iter is moved by the SampleIterator.
+// The random numbers are drawn from r, and the clones of the SampleIterator share r.
+func Sample(iter ConstIterator, rate float64, r *rand.Rand) *SampleIterator {
+	s := &SampleIterator{iter: iter, rate: rate, r: r}
+	s.skip()
+	return s
+}
+
+// IsValid returns whether iter is valid
+func (iter *SampleIterator) IsValid() bool {
+	return iter.iter.IsValid()
+}
+
+// Next moves the wrapped iterator to the next sampled element, or until it becomes invalid, and returns iter
+func (iter *SampleIterator) Next() ConstIterator {
+	if iter.iter.IsValid() {
+		iter.iter.Next()
+		iter.skip()
+	}
+	return iter
+}
+
+// skip moves the wrapped iterator forward until an element is sampled
+func (iter *SampleIterator) skip() {
+	for iter.iter.IsValid() && iter.r.Float64() >= iter.rate {
+		iter.iter.Next()
+	}
+}
+
+// Value returns the value of the wrapped iterator
+func (iter *SampleIterator) Value() interface{} {
+	return iter.iter.Value()
+}
+
+// Key returns the key of the wrapped iterator if it is a ConstKvIterator, otherwise returns nil
+func (iter *SampleIterator) Key() interface{} {
+	return keyOf(iter.iter)
+}
+
+// Clone clones iter to a new SampleIterator with a clone of the wrapped iterator
+func (iter *SampleIterator) Clone() ConstIterator {
+	return &SampleIterator{iter: iter.iter.Clone(), rate: iter.rate, r: iter.r}
+}
+
+// Equal returns whether the wrapped iterators of iter and other are at the same position
+func (iter *SampleIterator) Equal(other ConstIterator) bool {
+	return iter.iter.Equal(unwrap(other))
+}
+
+func keyOf(iter ConstIterator) interface{} {
+	if kvIter, ok := iter.(ConstKvIterator); ok {
+		return kvIter.Key()
+	}
+	return nil
+}
+
+// unwrap returns the iterator wrapped by a StepIterator or SampleIterator, or iter itself
+func unwrap(iter ConstIterator) ConstIterator {
+	switch it := iter.(type) {
+	case *StepIterator:
+		return it.iter
+	case *SampleIterator:
+		return it.iter
+	}
+	return iter
+}
@@ -0,0 +1,70 @@
+package iterator_test
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/ds/vector"
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStep(t *testing.T) {
+	m := treemap.New()
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i*10)
+	}
+	var keys, values []interface{}
+	for iter := iterator.Step(m.Begin(), 3); iter.IsValid(); iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	assert.Equal(t, []interface{}{0, 3, 6, 9}, keys)
+	assert.Equal(t, []interface{}{0, 30, 60, 90}, values)
+
+	iter := iterator.Step(m.Begin(), 4)
+	clone := iter.Clone()
+	iter.Next()
+	assert.Equal(t, 0, clone.(*iterator.StepIterator).Key())
+	assert.Equal(t, 4, iter.Key())
+	assert.True(t, iter.Equal(m.Find(4)))
+	assert.True(t, clone.Next().Equal(iter))
+
+	v := vector.New()
+	for i := 0; i < 5; i++ {
+		v.PushBack(i)
+	}
+	values = nil
+	for iter := iterator.Step(v.Begin(), 2); iter.IsValid(); iter.Next() {
+		values = append(values, iter.Value())
+	}
+	assert.Equal(t, []interface{}{0, 2, 4}, values)
+	assert.Nil(t, iterator.Step(v.Begin(), 1).Key())
+
+	assert.Panics(t, func() { iterator.Step(v.Begin(), 0) })
+}
+
+func TestSample(t *testing.T) {
+	m := treemap.New()
+	n := 10000
+	for i := 0; i < n; i++ {
+		m.Insert(i, i)
+	}
+	r := rand.New(rand.NewSource(1))
+	count := 0
+	last := -1
+	for iter := iterator.Sample(m.Begin(), 0.2, r); iter.IsValid(); iter.Next() {
+		assert.True(t, iter.Key().(int) > last)
+		last = iter.Key().(int)
+		count++
+	}
+	assert.True(t, math.Abs(float64(count)/float64(n)-0.2) < 0.02, "sampled %v of %v", count, n)
+
+	assert.False(t, iterator.Sample(m.Begin(), 0, r).IsValid())
+	count = 0
+	for iter := iterator.Sample(m.Begin(), 1, r); iter.IsValid(); iter.Next() {
+		count++
+	}
+	assert.Equal(t, n, count)
+}
@@ -0,0 +1,41 @@
+//go:build go1.18
+// +build go1.18
+
+package iterator
+
+// TypedIterator is a typed const iterator, its Value returns T instead of interface{}.
+// It is a convenience for the callers which know the type of the values, not a fast path:
+// the containers store their values as interface{}, so the values are still boxed and asserted one by one.
+type TypedIterator[T any] interface {
+	IsValid() bool
+	Next()
+	Value() T
+}
+
+// TypedAdapter adapts a ConstIterator to a TypedIterator. It only holds the wrapped iterator,
+// so it is used by value and wrapping an iterator doesn't allocate.
+type TypedAdapter[T any] struct {
+	iter ConstIterator
+}
+
+// Typed returns a TypedAdapter which wraps iter, the values of iter must be of type T.
+// The type assertion is only moved from the call site into Value, so it costs the same as iterating iter directly.
+func Typed[T any](iter ConstIterator) TypedAdapter[T] {
+	return TypedAdapter[T]{iter: iter}
+}
+
+// IsValid returns whether iter is valid
+func (iter TypedAdapter[T]) IsValid() bool {
+	return iter.iter.IsValid()
+}
+
+// Next moves iter to the next position
+func (iter TypedAdapter[T]) Next() {
+	iter.iter.Next()
+}
+
+// Value returns the value of iter as T, a nil value of an interface type T is returned as the zero value of T
+func (iter TypedAdapter[T]) Value() T {
+	v, _ := iter.iter.Value().(T)
+	return v
+}
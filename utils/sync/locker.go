@@ -14,6 +14,15 @@ type Locker interface {
 
 var _ Locker = (*gosync.RWMutex)(nil)
 
+// TryLocker is an optional interface of Locker, which can try to acquire the lock without blocking
+type TryLocker interface {
+	Locker
+	TryLock() bool
+	TryRLock() bool
+}
+
+var _ TryLocker = FakeLocker{}
+
 // FakeLocker is a fack locker
 type FakeLocker struct {
 }
@@ -37,3 +46,13 @@ func (l FakeLocker) RLock() {
 func (l FakeLocker) RUnlock() {
 
 }
+
+// TryLock does nothing and always returns true
+func (l FakeLocker) TryLock() bool {
+	return true
+}
+
+// TryRLock does nothing and always returns true
+func (l FakeLocker) TryRLock() bool {
+	return true
+}
@@ -0,0 +1,11 @@
+//go:build go1.18
+// +build go1.18
+
+package sync
+
+import (
+	gosync "sync"
+)
+
+// sync.RWMutex supports TryLock and TryRLock since go1.18
+var _ TryLocker = (*gosync.RWMutex)(nil)
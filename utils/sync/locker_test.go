@@ -0,0 +1,14 @@
+package sync
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFakeLocker(t *testing.T) {
+	var locker TryLocker = FakeLocker{}
+	locker.Lock()
+	assert.True(t, locker.TryLock())
+	assert.True(t, locker.TryRLock())
+	locker.Unlock()
+}
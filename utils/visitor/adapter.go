@@ -0,0 +1,30 @@
+package visitor
+
+// Collect returns a KvVisitor which appends every visited value to out
+func Collect(out *[]interface{}) KvVisitor {
+	return func(key, value interface{}) bool {
+		*out = append(*out, value)
+		return true
+	}
+}
+
+// Filter returns a KvVisitor which only forwards the key-value pairs satisfying pred to inner
+func Filter(pred func(key, value interface{}) bool, inner KvVisitor) KvVisitor {
+	return func(key, value interface{}) bool {
+		if !pred(key, value) {
+			return true
+		}
+		return inner(key, value)
+	}
+}
+
+// Limit returns a KvVisitor which forwards at most n key-value pairs to inner, and stops the traversal after that
+func Limit(n int, inner KvVisitor) KvVisitor {
+	return func(key, value interface{}) bool {
+		if n <= 0 {
+			return false
+		}
+		n--
+		return inner(key, value) && n > 0
+	}
+}
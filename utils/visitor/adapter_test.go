@@ -0,0 +1,35 @@
+package visitor_test
+
+import (
+	treemap "github.com/liyue201/gostl/ds/map"
+	"github.com/liyue201/gostl/utils/visitor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdapters(t *testing.T) {
+	m := treemap.New()
+	for i := 0; i < 20; i++ {
+		m.Insert(i, i*10)
+	}
+	isOdd := func(key, value interface{}) bool {
+		return key.(int)%2 == 1
+	}
+
+	// Limit inside Filter counts the matching pairs only
+	var values []interface{}
+	m.Traversal(visitor.Filter(isOdd, visitor.Limit(3, visitor.Collect(&values))))
+	assert.Equal(t, []interface{}{10, 30, 50}, values)
+
+	// Limit outside Filter counts all visited pairs
+	values = nil
+	m.Traversal(visitor.Limit(6, visitor.Filter(isOdd, visitor.Collect(&values))))
+	assert.Equal(t, []interface{}{10, 30, 50}, values)
+
+	values = nil
+	m.Traversal(visitor.Limit(0, visitor.Collect(&values)))
+	assert.Nil(t, values)
+
+	m.Traversal(visitor.Collect(&values))
+	assert.Equal(t, 20, len(values))
+}